@@ -0,0 +1,197 @@
+// Command base45 creates and restores paper backups of base 45 QR codes.
+//
+// Usage:
+//
+//	base45 paper [flags] <file>
+//	base45 restore [flags] <text file>...
+//
+// The paper command writes the pages as <out>-<n>.png and the text of all
+// parts, separated by blank lines, as <out>.txt. The restore command reads
+// typed parts in the same format and writes the original file.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/adrianrudnik/base45-go/paper"
+	"github.com/adrianrudnik/base45-go/qr"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "paper":
+		err = backup(os.Args[2:])
+	case "restore":
+		err = restore(os.Args[2:])
+	default:
+		usage()
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "base45 %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: base45 paper [flags] <file>")
+	fmt.Fprintln(os.Stderr, "       base45 restore [flags] <text file>...")
+	os.Exit(2)
+}
+
+// commonFlags registers the flags shared by both commands.
+func commonFlags(fs *flag.FlagSet, opts *paper.Options, passphraseFile *string) {
+	fs.IntVar(&opts.Parity, "parity", paper.DefaultParity, "Reed-Solomon parity bytes per block")
+	fs.StringVar(passphraseFile, "passphrase-file", "", "file holding the passphrase")
+}
+
+func backup(args []string) error {
+	var opts paper.Options
+	var passphraseFile, level string
+
+	fs := flag.NewFlagSet("paper", flag.ExitOnError)
+	commonFlags(fs, &opts, &passphraseFile)
+	fs.BoolVar(&opts.Compress, "compress", false, "compress the file first")
+	fs.IntVar(&opts.Version, "version", paper.DefaultVersion, "QR code version")
+	fs.StringVar(&level, "level", "M", "QR code error correction level (L, M, Q or H)")
+	out := fs.String("out", "backup", "prefix of the written files")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+	}
+
+	var err error
+
+	if opts.Level, err = parseLevel(level); err != nil {
+		return err
+	}
+
+	if opts.Passphrase, err = readPassphrase(passphraseFile); err != nil {
+		return err
+	}
+
+	in, err := os.ReadFile(fs.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	parts, err := paper.Encode(in, opts)
+
+	if err != nil {
+		return err
+	}
+
+	pages, err := paper.Render(parts, opts)
+
+	if err != nil {
+		return err
+	}
+
+	for i, page := range pages {
+		var buf bytes.Buffer
+
+		if err := png.Encode(&buf, page); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(fmt.Sprintf("%s-%d.png", *out, i+1), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	text := append(bytes.Join(parts, []byte("\n\n")), '\n')
+
+	return os.WriteFile(*out+".txt", text, 0644)
+}
+
+func restore(args []string) error {
+	var opts paper.Options
+	var passphraseFile string
+
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	commonFlags(fs, &opts, &passphraseFile)
+	out := fs.String("out", "", "restored file, standard output if empty")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		usage()
+	}
+
+	var err error
+
+	if opts.Passphrase, err = readPassphrase(passphraseFile); err != nil {
+		return err
+	}
+
+	var parts [][]byte
+
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+
+		if err != nil {
+			return err
+		}
+
+		p, err := paper.ParseText(f)
+		f.Close()
+
+		if err != nil {
+			return err
+		}
+
+		parts = append(parts, p...)
+	}
+
+	data, err := paper.Decode(parts, opts)
+
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+
+		return err
+	}
+
+	return os.WriteFile(*out, data, 0600)
+}
+
+func parseLevel(s string) (qr.Level, error) {
+	for l := qr.L; l <= qr.H; l++ {
+		if strings.EqualFold(s, l.String()) {
+			return l, nil
+		}
+	}
+
+	return 0, qr.ErrInvalidLevel
+}
+
+// readPassphrase reads the passphrase from the given file without the
+// trailing line break, or returns nil if no file is given.
+func readPassphrase(name string) ([]byte, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(b, "\r\n"), nil
+}
@@ -0,0 +1,182 @@
+// Package compression implements the zlib compression stage that usually
+// precedes base 45 encoding, with support for preset dictionaries as
+// described in https://datatracker.ietf.org/doc/rfc1950/
+package compression
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"hash/adler32"
+	"io"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+/*
+	Chapter references:
+
+	[1] https://datatracker.ietf.org/doc/rfc1950/
+        1996-05 rfc1950
+*/
+
+// zlibFlagDictionary is the FDICT bit of the zlib FLG header byte.
+const zlibFlagDictionary = 0x20
+
+// MaxDecompressedSize is the output limit of Decompress and Decode in bytes. It
+// keeps small crafted payloads from inflating into huge allocations, use
+// DecompressWithMaxSize for other limits.
+const MaxDecompressedSize = 1 << 20
+
+// DictionaryID returns the ID zlib uses to reference the given preset dictionary.
+func DictionaryID(dict []byte) uint32 {
+	/*
+		[1] Chapter 2.2:
+
+		DICTID
+			This field contains the ADLER-32 checksum of the preset
+			dictionary.  The dictionary ID is the ADLER-32 checksum of
+			the dictionary.
+	*/
+	return adler32.Checksum(dict)
+}
+
+// Compress deflates the given bytes with the best compression level.
+// If a non-empty dictionary is given, it is used as preset dictionary and its
+// ID is carried in the zlib header, so Decompress can pick the matching one.
+func Compress(in, dict []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	// zlib sets the FDICT flag for any non-nil dictionary, even an empty one.
+	if len(dict) == 0 {
+		dict = nil
+	}
+
+	w, err := zlib.NewWriterLevelDict(&buf, zlib.BestCompression, dict)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(in); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// HeaderDictionaryID returns the preset dictionary ID carried in the zlib
+// header of the given data. The boolean is false if no dictionary is referenced.
+func HeaderDictionaryID(in []byte) (uint32, bool, error) {
+	/*
+		[1] Chapter 2.2:
+
+		The FCHECK value must be such that CMF and FLG, when viewed as
+		a 16-bit unsigned integer stored in MSB order (CMF*256 + FLG),
+		is a multiple of 31.
+	*/
+	if len(in) < 2 || binary.BigEndian.Uint16(in)%31 != 0 {
+		return 0, false, ErrInvalidHeader
+	}
+
+	/*
+		[1] Chapter 2.2:
+
+		FDICT (Preset dictionary)
+			If FDICT is set, a DICT dictionary identifier is present
+			immediately after the FLG byte.
+	*/
+	if in[1]&zlibFlagDictionary == 0 {
+		return 0, false, nil
+	}
+
+	if len(in) < 6 {
+		return 0, false, ErrInvalidHeader
+	}
+
+	return binary.BigEndian.Uint32(in[2:6]), true, nil
+}
+
+// Decompress inflates the given zlib data. If the data references a preset
+// dictionary, the matching one is picked by its ID from the given dictionaries.
+// Output beyond MaxDecompressedSize is rejected with ErrOutputTooLarge.
+func Decompress(in []byte, dicts ...[]byte) ([]byte, error) {
+	return DecompressWithMaxSize(in, MaxDecompressedSize, dicts...)
+}
+
+// DecompressWithMaxSize works like Decompress, but rejects data that inflates
+// to more than maxSize bytes with ErrOutputTooLarge.
+func DecompressWithMaxSize(in []byte, maxSize int, dicts ...[]byte) ([]byte, error) {
+	id, hasDict, err := HeaderDictionaryID(in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var dict []byte
+
+	if hasDict {
+		found := false
+
+		for _, d := range dicts {
+			if DictionaryID(d) == id {
+				dict = d
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, ErrUnknownDictionary
+		}
+	}
+
+	r, err := zlib.NewReaderDict(bytes.NewReader(in), dict)
+
+	if err != nil {
+		return nil, ErrInvalidCompressedData
+	}
+
+	defer r.Close()
+
+	// Read one byte more than allowed to tell a full result from a cut one.
+	out, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+
+	if err != nil {
+		return nil, ErrInvalidCompressedData
+	}
+
+	if len(out) > maxSize {
+		return nil, ErrOutputTooLarge
+	}
+
+	return out, nil
+}
+
+// Encode compresses the given bytes with the optional dictionary and encodes
+// the result to base 45.
+func Encode(in, dict []byte) ([]byte, error) {
+	compressed, err := Compress(in, dict)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return base45.Encode(compressed), nil
+}
+
+// Decode decodes the given base 45 data and decompresses it, picking the
+// preset dictionary referenced by the payload from the given dictionaries.
+func Decode(in []byte, dicts ...[]byte) ([]byte, error) {
+	compressed, err := base45.Decode(in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Decompress(compressed, dicts...)
+}
@@ -0,0 +1,154 @@
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+// sampleDocument returns a small CBOR document shaped like a DCC vaccination entry.
+func sampleDocument(name, dob string) []byte {
+	doc := []byte{0xa4}
+	doc = append(doc, cborTextStrings("ver", "1.3.0", "nam")...)
+	doc = append(doc, 0xa2)
+	doc = append(doc, cborTextStrings("fn", name, "gn", name)...)
+	doc = append(doc, cborTextStrings("dob", dob, "v")...)
+	doc = append(doc, 0x81, 0xa6)
+	doc = append(doc, cborTextStrings("tg", "840539006", "mp", "EU/1/20/1528", "ma", "ORG-100030215")...)
+	doc = append(doc, cborTextStrings("co", "DE", "is", "Robert Koch-Institut", "ci", "URN:UVCI:01:DE:"+name)...)
+
+	return doc
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	expected := sampleDocument("Mustermann", "1964-08-12")
+
+	for _, dict := range [][]byte{nil, DefaultDictionary} {
+		compressed, err := Compress(expected, dict)
+
+		if err != nil {
+			t.Fatalf("Expected compressed data, got error \"%s\"", err)
+		}
+
+		got, err := Decompress(compressed, DefaultDictionary)
+
+		if err != nil {
+			t.Fatalf("Expected decompressed data, got error \"%s\"", err)
+		}
+
+		if !bytes.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestDefaultDictionaryShrinksPayload(t *testing.T) {
+	doc := sampleDocument("Mustermann", "1964-08-12")
+
+	plain, _ := Compress(doc, nil)
+	withDict, _ := Compress(doc, DefaultDictionary)
+
+	if len(withDict) >= len(plain) {
+		t.Errorf("Expected dictionary to shrink payload, got %d bytes with and %d without", len(withDict), len(plain))
+	}
+}
+
+func TestDecompressPicksDictionaryByID(t *testing.T) {
+	other := []byte("some unrelated dictionary content")
+	expected := sampleDocument("Mustermann", "1964-08-12")
+
+	compressed, _ := Compress(expected, DefaultDictionary)
+
+	id, ok, err := HeaderDictionaryID(compressed)
+
+	if err != nil || !ok || id != DictionaryID(DefaultDictionary) {
+		t.Fatalf("Expected dictionary id %d in header, got %d (%v, %v)", DictionaryID(DefaultDictionary), id, ok, err)
+	}
+
+	got, err := Decompress(compressed, other, DefaultDictionary)
+
+	if err != nil {
+		t.Fatalf("Expected decompressed data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestDecompressUnknownDictionary(t *testing.T) {
+	compressed, _ := Compress([]byte("Hello!!"), DefaultDictionary)
+
+	_, err := Decompress(compressed, []byte("another dictionary"))
+
+	if err != ErrUnknownDictionary {
+		t.Errorf("Expected ErrUnknownDictionary, got \"%v\"", err)
+	}
+}
+
+func TestDecompressInvalidHeader(t *testing.T) {
+	_, err := Decompress([]byte{0x78, 0x00})
+
+	if err != ErrInvalidHeader {
+		t.Errorf("Expected ErrInvalidHeader, got \"%v\"", err)
+	}
+}
+
+func TestDecompressTruncatedData(t *testing.T) {
+	compressed, _ := Compress([]byte("Hello!!"), nil)
+
+	_, err := Decompress(compressed[:len(compressed)-3])
+
+	if err != ErrInvalidCompressedData {
+		t.Errorf("Expected ErrInvalidCompressedData, got \"%v\"", err)
+	}
+}
+
+func TestDecompressOutputTooLarge(t *testing.T) {
+	compressed, _ := Compress(make([]byte, MaxDecompressedSize+1), nil)
+
+	if len(compressed) > 2048 {
+		t.Fatalf("Expected a small payload, got %d bytes", len(compressed))
+	}
+
+	if _, err := Decompress(compressed); err != ErrOutputTooLarge {
+		t.Errorf("Expected ErrOutputTooLarge, got \"%v\"", err)
+	}
+
+	if _, err := DecompressWithMaxSize(compressed, MaxDecompressedSize+1); err != nil {
+		t.Errorf("Expected no error at the exact limit, got \"%v\"", err)
+	}
+
+	if _, err := DecompressWithMaxSize(compressed, 100); err != ErrOutputTooLarge {
+		t.Errorf("Expected ErrOutputTooLarge, got \"%v\"", err)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	expected := sampleDocument("Mustermann", "1964-08-12")
+
+	enc, err := Encode(expected, DefaultDictionary)
+
+	if err != nil {
+		t.Fatalf("Expected encoded data, got error \"%s\"", err)
+	}
+
+	got, err := Decode(enc, DefaultDictionary)
+
+	if err != nil {
+		t.Fatalf("Expected decoded data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestDecodeInvalidBase45(t *testing.T) {
+	_, err := Decode([]byte("GGW"))
+
+	if err != base45.ErrInvalidEncodedDataOverflow {
+		t.Errorf("Expected ErrInvalidEncodedDataOverflow, got \"%v\"", err)
+	}
+}
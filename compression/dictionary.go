@@ -0,0 +1,41 @@
+package compression
+
+// DefaultDictionary is a preset dictionary for DCC-like CBOR documents.
+// It consists of CBOR encoded text strings of the map keys and common values
+// used by EU digital COVID certificates, ordered from rare to frequent.
+var DefaultDictionary = cborTextStrings(
+	// recovery entries
+	"fr", "df", "du",
+	// test entries
+	"tt", "nm", "sc", "tr", "tc", "LP6464-4", "LP217198-3", "260415000", "260373001",
+	// vaccination entries
+	"EU/1/20/1507", "EU/1/21/1529", "EU/1/20/1525", "ORG-100031184", "ORG-100001699",
+	"ORG-100001417", "1119305005", "J07BX03", "EU/1/20/1528", "ORG-100030215", "1119349007",
+	"vp", "mp", "dn", "sd", "dt",
+	// shared entries
+	"URN:UVCI:01:", "840539006", "tg", "ma", "co", "is", "ci",
+	"1.3.0", "ver", "fn", "fnt", "gn", "gnt", "nam", "dob", "v", "t", "r",
+)
+
+// cborTextStrings concatenates the given strings as CBOR text strings.
+// Only strings of up to 255 bytes are supported, it panics for longer ones.
+func cborTextStrings(values ...string) []byte {
+	var out []byte
+
+	for _, v := range values {
+		// major type 3 (text string), short lengths are stored in the
+		// additional information, longer ones in one following byte
+		switch {
+		case len(v) < 24:
+			out = append(out, 0x60|byte(len(v)))
+		case len(v) <= 0xff:
+			out = append(out, 0x78, byte(len(v)))
+		default:
+			panic("compression: text string too long")
+		}
+
+		out = append(out, v...)
+	}
+
+	return out
+}
@@ -0,0 +1,36 @@
+package compression
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCborTextStrings(t *testing.T) {
+	long := strings.Repeat("a", 25)
+
+	for _, entry := range []struct {
+		value    string
+		expected []byte
+	}{
+		{"", []byte{0x60}},
+		{"ver", []byte{0x63, 'v', 'e', 'r'}},
+		{long[:23], append([]byte{0x77}, long[:23]...)},
+		{long[:24], append([]byte{0x78, 24}, long[:24]...)},
+		{long, append([]byte{0x78, 25}, long...)},
+	} {
+		if got := cborTextStrings(entry.value); !bytes.Equal(got, entry.expected) {
+			t.Errorf("Unexpected encoding for a %d byte string, expected %x, got %x", len(entry.value), entry.expected, got)
+		}
+	}
+}
+
+func TestCborTextStringsTooLong(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a 256 byte string")
+		}
+	}()
+
+	cborTextStrings(strings.Repeat("a", 256))
+}
@@ -0,0 +1,16 @@
+package compression
+
+import "errors"
+
+// ErrInvalidHeader means the given data does not start with a valid zlib header.
+var ErrInvalidHeader = errors.New("invalid zlib header")
+
+// ErrUnknownDictionary means the compressed data references a preset dictionary
+// by its ID, but no dictionary with a matching ID was given to the decompressor.
+var ErrUnknownDictionary = errors.New("unknown preset dictionary")
+
+// ErrInvalidCompressedData means the compressed data could not be inflated.
+var ErrInvalidCompressedData = errors.New("invalid compressed data")
+
+// ErrOutputTooLarge means the data inflates to more than the allowed size.
+var ErrOutputTooLarge = errors.New("decompressed data too large")
@@ -0,0 +1,112 @@
+package compression
+
+import (
+	"bytes"
+	"sort"
+)
+
+const (
+	// maxDictionarySize is the deflate window size, bytes of a dictionary
+	// beyond it can never be referenced by the compressor.
+	maxDictionarySize = 32768
+
+	// Substrings shorter than minSegmentLength are cheaper to emit as
+	// literals, longer ones than maxSegmentLength rarely repeat in the
+	// short documents this package is meant for.
+	minSegmentLength = 4
+	maxSegmentLength = 32
+)
+
+type segment struct {
+	value []byte
+	score int
+}
+
+// Train builds a preset dictionary of at most size bytes from the given samples.
+// Substrings shared by many samples are preferred, and the most valuable ones are
+// placed at the end of the dictionary where references to them are the cheapest.
+// The result is deterministic for the same samples, and nil if nothing is worth
+// adding to the dictionary.
+func Train(samples [][]byte, size int) []byte {
+	if size > maxDictionarySize {
+		size = maxDictionarySize
+	}
+
+	if size <= 0 {
+		return nil
+	}
+
+	// Count in how many samples each substring occurs, as repetitions inside a
+	// single sample are already covered by the compressor itself.
+	occurrences := make(map[string]int)
+
+	for _, sample := range samples {
+		seen := make(map[string]bool)
+
+		for i := 0; i < len(sample); i++ {
+			for l := minSegmentLength; l <= maxSegmentLength && i+l <= len(sample); l++ {
+				seen[string(sample[i:i+l])] = true
+			}
+		}
+
+		for s := range seen {
+			occurrences[s]++
+		}
+	}
+
+	segments := make([]segment, 0, len(occurrences))
+
+	for s, n := range occurrences {
+		if n < 2 {
+			continue
+		}
+
+		segments = append(segments, segment{value: []byte(s), score: n * len(s)})
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		if segments[i].score != segments[j].score {
+			return segments[i].score > segments[j].score
+		}
+
+		return bytes.Compare(segments[i].value, segments[j].value) < 0
+	})
+
+	var picked [][]byte
+	total := 0
+
+	for _, seg := range segments {
+		if total+len(seg.value) > size {
+			continue
+		}
+
+		// Skip segments that are already covered by a picked one.
+		covered := false
+
+		for _, p := range picked {
+			if bytes.Contains(p, seg.value) {
+				covered = true
+				break
+			}
+		}
+
+		if covered {
+			continue
+		}
+
+		picked = append(picked, seg.value)
+		total += len(seg.value)
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	dict := make([]byte, 0, total)
+
+	for i := len(picked) - 1; i >= 0; i-- {
+		dict = append(dict, picked[i]...)
+	}
+
+	return dict
+}
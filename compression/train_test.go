@@ -0,0 +1,84 @@
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func trainingCorpus() [][]byte {
+	var samples [][]byte
+
+	for i := 0; i < 20; i++ {
+		samples = append(samples, sampleDocument(fmt.Sprintf("Person%02d", i), fmt.Sprintf("19%02d-01-01", 50+i)))
+	}
+
+	return samples
+}
+
+func TestTrainImprovesCompression(t *testing.T) {
+	dict := Train(trainingCorpus(), 1024)
+	doc := sampleDocument("Mustermann", "1964-08-12")
+
+	plain, _ := Compress(doc, nil)
+	withDict, _ := Compress(doc, dict)
+
+	if len(withDict) >= len(plain) {
+		t.Errorf("Expected trained dictionary to shrink payload, got %d bytes with and %d without", len(withDict), len(plain))
+	}
+
+	got, err := Decompress(withDict, dict)
+
+	if err != nil || !bytes.Equal(got, doc) {
+		t.Errorf("Expected round trip with trained dictionary, got error \"%v\"", err)
+	}
+}
+
+func TestTrainRespectsSize(t *testing.T) {
+	dict := Train(trainingCorpus(), 64)
+
+	if len(dict) > 64 {
+		t.Errorf("Expected dictionary of at most 64 bytes, got %d", len(dict))
+	}
+}
+
+func TestTrainIsDeterministic(t *testing.T) {
+	a := Train(trainingCorpus(), 512)
+	b := Train(trainingCorpus(), 512)
+
+	if !bytes.Equal(a, b) {
+		t.Errorf("Expected identical dictionaries for identical samples")
+	}
+}
+
+func TestTrainWithoutSamples(t *testing.T) {
+	if dict := Train(nil, 512); len(dict) != 0 {
+		t.Errorf("Expected empty dictionary, got %v", dict)
+	}
+}
+
+func TestCompressWithEmptyTrainedDictionary(t *testing.T) {
+	expected := sampleDocument("Musterfrau-Gabler", "1964-08-12")
+
+	for _, dict := range [][]byte{Train(nil, 512), Train(trainingCorpus(), 0), {}} {
+		compressed, err := Compress(expected, dict)
+
+		if err != nil {
+			t.Fatalf("Expected compressed data, got error \"%s\"", err)
+		}
+
+		if _, hasDict, _ := HeaderDictionaryID(compressed); hasDict {
+			t.Errorf("Expected no preset dictionary flag for an empty dictionary")
+		}
+
+		actual, err := Decompress(compressed)
+
+		if err != nil {
+			t.Fatalf("Expected decompressed data, got error \"%s\"", err)
+		}
+
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("Expected \"%s\", got \"%s\"", expected, actual)
+		}
+	}
+}
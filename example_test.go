@@ -29,3 +29,11 @@ func ExampleDecode_errorHandling() {
 		fmt.Printf("Encountered invalid data")
 	}
 }
+
+func ExampleSuggest() {
+	suggestions := Suggest([]byte("%69 VD92EXo"), nil)
+
+	if len(suggestions) > 0 {
+		fmt.Printf("Did you mean: %s", suggestions[0].Encoded)
+	}
+}
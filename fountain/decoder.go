@@ -0,0 +1,172 @@
+package fountain
+
+import (
+	"math/bits"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+// Decoder reconstructs a message from its symbols.
+// The zero value is not usable, use NewDecoder instead.
+type Decoder struct {
+	header   header
+	k        int
+	cdf      []float64
+	rows     map[int]*row
+	received int
+}
+
+// row is a linear combination of blocks over GF(2), kept in reduced row
+// echelon form by the decoder, so once all K pivots exist, every row holds
+// exactly one block.
+type row struct {
+	coefficients []uint64
+	data         []byte
+}
+
+// NewDecoder returns an empty Decoder.
+func NewDecoder() *Decoder {
+	return &Decoder{
+		rows: make(map[int]*row),
+	}
+}
+
+// Add decodes the given base 45 encoded symbol and adds it to the message.
+// The first added symbol determines the message, symbols of other messages are
+// rejected with ErrMessageMismatch. Damaged symbols are rejected with
+// ErrSymbolChecksumMismatch and leave the decoder untouched, so the caller can
+// keep scanning. It reports whether the message is complete.
+func (d *Decoder) Add(in []byte) (bool, error) {
+	symbol, err := base45.Decode(in)
+
+	if err != nil {
+		return d.Complete(), err
+	}
+
+	if len(symbol) < HeaderSize {
+		return d.Complete(), ErrInvalidSymbol
+	}
+
+	if !verify(symbol) {
+		return d.Complete(), ErrSymbolChecksumMismatch
+	}
+
+	h, id := unmarshalHeader(symbol)
+
+	if err := h.validate(); err != nil || len(symbol) != HeaderSize+h.blockSize {
+		return d.Complete(), ErrInvalidSymbol
+	}
+
+	if d.k == 0 {
+		d.header = h
+		d.k = h.blocks()
+		d.cdf = distribution(d.k)
+	} else if d.header != h {
+		return d.Complete(), ErrMessageMismatch
+	}
+
+	d.received++
+
+	if d.Complete() {
+		return true, nil
+	}
+
+	r := &row{
+		coefficients: make([]uint64, (d.k+63)/64),
+		data:         append([]byte{}, symbol[HeaderSize:]...),
+	}
+
+	for _, n := range neighbours(id, d.k, d.cdf) {
+		r.coefficients[n/64] |= 1 << (n % 64)
+	}
+
+	d.insert(r)
+
+	return d.Complete(), nil
+}
+
+// insert reduces the row by all known pivots and, if it is still independent,
+// adds it as new pivot row and eliminates its pivot from all other rows.
+func (d *Decoder) insert(r *row) {
+	for pivot, other := range d.rows {
+		if r.has(pivot) {
+			r.xor(other)
+		}
+	}
+
+	pivot := r.firstColumn()
+
+	if pivot < 0 {
+		// The symbol carried no new information.
+		return
+	}
+
+	for _, other := range d.rows {
+		if other.has(pivot) {
+			other.xor(r)
+		}
+	}
+
+	d.rows[pivot] = r
+}
+
+func (r *row) has(column int) bool {
+	return r.coefficients[column/64]&(1<<(column%64)) != 0
+}
+
+func (r *row) xor(other *row) {
+	for i := range r.coefficients {
+		r.coefficients[i] ^= other.coefficients[i]
+	}
+
+	xorInto(r.data, other.data)
+}
+
+func (r *row) firstColumn() int {
+	for i, word := range r.coefficients {
+		if word != 0 {
+			return i*64 + bits.TrailingZeros64(word)
+		}
+	}
+
+	return -1
+}
+
+// Progress returns the number of independent symbols collected so far and the
+// number of blocks needed to reconstruct the message. Both are 0 until the
+// first symbol has been added.
+func (d *Decoder) Progress() (int, int) {
+	return len(d.rows), d.k
+}
+
+// Received returns the number of valid symbols added, including redundant ones.
+func (d *Decoder) Received() int {
+	return d.received
+}
+
+// Complete reports whether enough symbols have been added to reconstruct the message.
+func (d *Decoder) Complete() bool {
+	return d.k > 0 && len(d.rows) == d.k
+}
+
+// Bytes returns the reconstructed message.
+// If not enough symbols have been added, ErrIncomplete is returned.
+func (d *Decoder) Bytes() ([]byte, error) {
+	if !d.Complete() {
+		return nil, ErrIncomplete
+	}
+
+	out := make([]byte, 0, d.k*d.header.blockSize)
+
+	for i := 0; i < d.k; i++ {
+		out = append(out, d.rows[i].data...)
+	}
+
+	out = out[:d.header.length]
+
+	if messageChecksum(out) != d.header.checksum {
+		return nil, ErrChecksumMismatch
+	}
+
+	return out, nil
+}
@@ -0,0 +1,192 @@
+package fountain
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestDecodeWithDroppedFrames(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, drop := range []float64{0, 0.3, 0.7} {
+		expected := make([]byte, 4000)
+		rng.Read(expected)
+
+		e, _ := NewEncoder(expected, 100)
+		d := NewDecoder()
+
+		sent := 0
+
+		for !d.Complete() {
+			symbol := e.Next()
+			sent++
+
+			if sent > 10*e.Blocks() {
+				t.Fatalf("Expected decoding to finish with %.0f%% dropped frames, progress stalled", drop*100)
+			}
+
+			if rng.Float64() < drop {
+				continue
+			}
+
+			if _, err := d.Add(symbol); err != nil {
+				t.Fatalf("Expected symbol to be added, got error \"%s\"", err)
+			}
+		}
+
+		got, err := d.Bytes()
+
+		if err != nil {
+			t.Fatalf("Expected message, got error \"%s\"", err)
+		}
+
+		if !bytes.Equal(got, expected) {
+			t.Errorf("Decoded message not equal to the original with %.0f%% dropped frames", drop*100)
+		}
+
+		if drop == 0 && d.Received() != e.Blocks() {
+			t.Errorf("Expected lossless transfer to need %d symbols, got %d", e.Blocks(), d.Received())
+		}
+	}
+}
+
+func TestDecodeWithoutSystematicSymbols(t *testing.T) {
+	expected := bytes.Repeat([]byte("0123456789"), 50)
+	e, _ := NewEncoder(expected, 20)
+	d := NewDecoder()
+
+	for id := uint32(e.Blocks()); !d.Complete(); id++ {
+		if id > uint32(20*e.Blocks()) {
+			t.Fatalf("Expected decoding to finish from encoded symbols only")
+		}
+
+		d.Add(e.Symbol(id))
+	}
+
+	got, _ := d.Bytes()
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Decoded message not equal to the original")
+	}
+}
+
+func TestDecodeProgress(t *testing.T) {
+	e, _ := NewEncoder(make([]byte, 100), 10)
+	d := NewDecoder()
+
+	if have, need := d.Progress(); have != 0 || need != 0 {
+		t.Errorf("Expected no progress before the first symbol, got %d of %d", have, need)
+	}
+
+	d.Add(e.Symbol(0))
+	d.Add(e.Symbol(0))
+
+	if have, need := d.Progress(); have != 1 || need != 10 {
+		t.Errorf("Expected progress of 1 of 10, got %d of %d", have, need)
+	}
+
+	if _, err := d.Bytes(); err != ErrIncomplete {
+		t.Errorf("Expected ErrIncomplete, got \"%v\"", err)
+	}
+}
+
+func TestDecodeMessageMismatch(t *testing.T) {
+	a, _ := NewEncoder([]byte("Hello, fountain!"), 4)
+	b, _ := NewEncoder([]byte("Hello, Fountain!"), 4)
+	d := NewDecoder()
+	d.Add(a.Next())
+
+	_, err := d.Add(b.Next())
+
+	if err != ErrMessageMismatch {
+		t.Errorf("Expected ErrMessageMismatch, got \"%v\"", err)
+	}
+}
+
+func TestDecodeChecksumMismatch(t *testing.T) {
+	e, _ := NewEncoder([]byte("Hello, fountain!"), 4)
+	d := NewDecoder()
+
+	for i := 0; i < e.Blocks(); i++ {
+		raw, _ := base45.Decode(e.Next())
+
+		if i == 0 {
+			raw[HeaderSize] ^= 0x01
+			seal(raw)
+		}
+
+		d.Add(base45.Encode(raw))
+	}
+
+	_, err := d.Bytes()
+
+	if err != ErrChecksumMismatch {
+		t.Errorf("Expected ErrChecksumMismatch, got \"%v\"", err)
+	}
+}
+
+func TestDecodeCorruptedSymbol(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	expected := make([]byte, 2000)
+	rng.Read(expected)
+
+	e, _ := NewEncoder(expected, 100)
+	d := NewDecoder()
+
+	corrupted := false
+
+	for sent := 0; !d.Complete(); sent++ {
+		if sent > 10*e.Blocks() {
+			t.Fatalf("Expected decoding to finish, progress stalled")
+		}
+
+		symbol := e.Next()
+
+		if rng.Float64() < 0.3 {
+			continue
+		}
+
+		if !corrupted {
+			raw, _ := base45.Decode(symbol)
+			raw[HeaderSize+5] ^= 0x40
+
+			if _, err := d.Add(base45.Encode(raw)); err != ErrSymbolChecksumMismatch {
+				t.Fatalf("Expected ErrSymbolChecksumMismatch, got \"%v\"", err)
+			}
+
+			if d.Received() != 0 {
+				t.Errorf("Expected corrupted symbol to be discarded, got %d received", d.Received())
+			}
+
+			corrupted = true
+
+			continue
+		}
+
+		if _, err := d.Add(symbol); err != nil {
+			t.Fatalf("Expected no error, got \"%v\"", err)
+		}
+	}
+
+	actual, err := d.Bytes()
+
+	if err != nil {
+		t.Fatalf("Expected no error, got \"%v\"", err)
+	}
+
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("Expected decoded message to match the input")
+	}
+}
+
+func TestDecodeInvalidSymbol(t *testing.T) {
+	_, err := NewDecoder().Add(base45.Encode([]byte("short")))
+
+	if err != ErrInvalidSymbol {
+		t.Errorf("Expected ErrInvalidSymbol, got \"%v\"", err)
+	}
+}
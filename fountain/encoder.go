@@ -0,0 +1,81 @@
+package fountain
+
+import (
+	"github.com/adrianrudnik/base45-go"
+)
+
+// Encoder produces the symbols of a single message.
+type Encoder struct {
+	header header
+	blocks [][]byte
+	cdf    []float64
+	next   uint32
+}
+
+// NewEncoder splits the data into blocks of blockSize bytes and returns an
+// Encoder for its symbols. The last block is padded with zeros.
+func NewEncoder(in []byte, blockSize int) (*Encoder, error) {
+	h := header{
+		length:    len(in),
+		blockSize: blockSize,
+		checksum:  messageChecksum(in),
+	}
+
+	if err := h.validate(); err != nil {
+		return nil, err
+	}
+
+	k := h.blocks()
+	padded := make([]byte, k*blockSize)
+	copy(padded, in)
+
+	blocks := make([][]byte, k)
+
+	for i := range blocks {
+		blocks[i] = padded[i*blockSize : (i+1)*blockSize]
+	}
+
+	return &Encoder{
+		header: h,
+		blocks: blocks,
+		cdf:    distribution(k),
+	}, nil
+}
+
+// Blocks returns the number of blocks the message is split into, which is the
+// minimum number of symbols a decoder needs.
+func (e *Encoder) Blocks() int {
+	return len(e.blocks)
+}
+
+// Next returns the next base 45 encoded symbol of the stream.
+// The stream starts with the plain blocks and never ends.
+func (e *Encoder) Next() []byte {
+	id := e.next
+	e.next++
+
+	return e.Symbol(id)
+}
+
+// Symbol returns the base 45 encoded symbol with the given ID.
+func (e *Encoder) Symbol(id uint32) []byte {
+	out := make([]byte, HeaderSize+e.header.blockSize)
+	e.header.marshal(out, id)
+
+	payload := out[HeaderSize:]
+
+	for _, n := range neighbours(id, len(e.blocks), e.cdf) {
+		xorInto(payload, e.blocks[n])
+	}
+
+	seal(out)
+
+	return base45.Encode(out)
+}
+
+// xorInto XORs src into dst, both need to have the same length.
+func xorInto(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
@@ -0,0 +1,59 @@
+package fountain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestEncoderIsDeterministic(t *testing.T) {
+	data := bytes.Repeat([]byte("fountain"), 100)
+
+	a, _ := NewEncoder(data, 64)
+	b, _ := NewEncoder(data, 64)
+
+	for id := uint32(0); id < 50; id++ {
+		if !bytes.Equal(a.Next(), b.Symbol(id)) {
+			t.Errorf("Expected symbol %d to be identical for identical input", id)
+		}
+	}
+}
+
+func TestEncoderSymbolsAreValidBase45(t *testing.T) {
+	e, _ := NewEncoder([]byte("Hello!!"), 4)
+
+	if e.Blocks() != 2 {
+		t.Errorf("Expected 2 blocks, got %d", e.Blocks())
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := base45.Decode(e.Next()); err != nil {
+			t.Errorf("Expected valid base 45 symbol, got error \"%s\"", err)
+		}
+	}
+}
+
+func TestEncoderEmptyInput(t *testing.T) {
+	_, err := NewEncoder([]byte{}, 64)
+
+	if err != ErrEmptyInput {
+		t.Errorf("Expected ErrEmptyInput, got \"%v\"", err)
+	}
+}
+
+func TestEncoderInvalidBlockSize(t *testing.T) {
+	for _, blockSize := range []int{0, -1, 65536} {
+		if _, err := NewEncoder([]byte("Hello!!"), blockSize); err != ErrInvalidBlockSize {
+			t.Errorf("Expected ErrInvalidBlockSize for block size %d, got \"%v\"", blockSize, err)
+		}
+	}
+}
+
+func TestEncoderTooManyBlocks(t *testing.T) {
+	_, err := NewEncoder(make([]byte, MaxBlocks+1), 1)
+
+	if err != ErrTooManyBlocks {
+		t.Errorf("Expected ErrTooManyBlocks, got \"%v\"", err)
+	}
+}
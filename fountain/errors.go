@@ -0,0 +1,30 @@
+package fountain
+
+import "errors"
+
+// ErrEmptyInput means that there is no data to encode.
+var ErrEmptyInput = errors.New("empty input value")
+
+// ErrInvalidBlockSize means the block size is outside of the supported range.
+var ErrInvalidBlockSize = errors.New("invalid block size")
+
+// ErrTooManyBlocks means the data would be split into more blocks than supported.
+var ErrTooManyBlocks = errors.New("too many blocks")
+
+// ErrInvalidSymbol means a symbol is too short or its header does not
+// match its payload.
+var ErrInvalidSymbol = errors.New("invalid symbol")
+
+// ErrSymbolChecksumMismatch means a symbol was damaged, for example by a
+// misread QR code, and has been discarded.
+var ErrSymbolChecksumMismatch = errors.New("symbol checksum mismatch")
+
+// ErrMessageMismatch means a symbol belongs to a different message than the
+// symbols added before.
+var ErrMessageMismatch = errors.New("symbol belongs to a different message")
+
+// ErrIncomplete means not enough symbols have been added to reconstruct the message.
+var ErrIncomplete = errors.New("message is incomplete")
+
+// ErrChecksumMismatch means the reconstructed message does not match its checksum.
+var ErrChecksumMismatch = errors.New("message checksum mismatch")
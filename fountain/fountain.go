@@ -0,0 +1,214 @@
+// Package fountain implements a rateless LT fountain code for transferring
+// data through a looping sequence of base 45 encoded QR codes.
+//
+// The data is split into K blocks of equal size. The encoder produces an
+// unbounded stream of symbols, each one the XOR of a pseudo-random set of
+// blocks, and the decoder reconstructs the data from any set of symbols that
+// covers all blocks, regardless of which frames were missed. The first K
+// symbols carry the plain blocks, so a lossless transfer needs no overhead.
+//
+// Every symbol starts with an 18 byte header, followed by the XORed block:
+//
+//	message length (4) | block size (2) | CRC-32 of the message (4) | symbol ID (4) | CRC-32 of the symbol (4)
+//
+// The symbol checksum covers the other header fields and the payload, so a
+// misread frame is rejected before it can corrupt the decoder state. All
+// values are big endian. The blocks of a symbol are derived from its ID
+// alone, so encoder and decoder do not need to share any other state.
+package fountain
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+)
+
+const (
+	// HeaderSize is the size of the binary header of each symbol in bytes.
+	HeaderSize = 18
+
+	// MaxBlocks is the maximum number of blocks a message can be split into.
+	MaxBlocks = 4096
+)
+
+// Parameters of the robust soliton distribution, see
+// https://doi.org/10.1109/SFCS.2002.1181950 chapter 5.
+const (
+	solitonC     = 0.1
+	solitonDelta = 0.5
+)
+
+// header describes the message a symbol belongs to.
+type header struct {
+	length    int
+	blockSize int
+	checksum  uint32
+}
+
+// blocks returns the number of blocks K the message is split into.
+func (h header) blocks() int {
+	return (h.length + h.blockSize - 1) / h.blockSize
+}
+
+// validate checks the header values describe a message this package can handle.
+func (h header) validate() error {
+	if h.length == 0 {
+		return ErrEmptyInput
+	}
+
+	if h.blockSize <= 0 || h.blockSize > math.MaxUint16 {
+		return ErrInvalidBlockSize
+	}
+
+	if h.blocks() > MaxBlocks {
+		return ErrTooManyBlocks
+	}
+
+	return nil
+}
+
+// marshal writes the header with the given symbol ID into dst. The symbol
+// checksum is written separately by seal once the payload is in place.
+func (h header) marshal(dst []byte, id uint32) {
+	binary.BigEndian.PutUint32(dst[0:4], uint32(h.length))
+	binary.BigEndian.PutUint16(dst[4:6], uint16(h.blockSize))
+	binary.BigEndian.PutUint32(dst[6:10], h.checksum)
+	binary.BigEndian.PutUint32(dst[10:14], id)
+}
+
+// unmarshalHeader reads the header and symbol ID from src.
+func unmarshalHeader(src []byte) (header, uint32) {
+	h := header{
+		length:    int(binary.BigEndian.Uint32(src[0:4])),
+		blockSize: int(binary.BigEndian.Uint16(src[4:6])),
+		checksum:  binary.BigEndian.Uint32(src[6:10]),
+	}
+
+	return h, binary.BigEndian.Uint32(src[10:14])
+}
+
+// symbolChecksum returns the CRC-32 of a symbol, skipping its checksum field.
+func symbolChecksum(symbol []byte) uint32 {
+	sum := crc32.ChecksumIEEE(symbol[0:14])
+
+	return crc32.Update(sum, crc32.IEEETable, symbol[HeaderSize:])
+}
+
+// seal writes the checksum of a complete symbol into its header.
+func seal(symbol []byte) {
+	binary.BigEndian.PutUint32(symbol[14:18], symbolChecksum(symbol))
+}
+
+// verify reports whether the checksum of a symbol matches its content.
+func verify(symbol []byte) bool {
+	return binary.BigEndian.Uint32(symbol[14:18]) == symbolChecksum(symbol)
+}
+
+// messageChecksum returns the checksum stored in the header for the given message.
+func messageChecksum(in []byte) uint32 {
+	return crc32.ChecksumIEEE(in)
+}
+
+// distribution returns the cumulative robust soliton distribution for k blocks,
+// where cdf[d-1] is the probability of a degree of at most d.
+func distribution(k int) []float64 {
+	r := solitonC * math.Log(float64(k)/solitonDelta) * math.Sqrt(float64(k))
+	spike := int(float64(k) / r)
+
+	weights := make([]float64, k)
+	sum := 0.0
+
+	for d := 1; d <= k; d++ {
+		// ideal soliton
+		if d == 1 {
+			weights[d-1] = 1 / float64(k)
+		} else {
+			weights[d-1] = 1 / float64(d*(d-1))
+		}
+
+		// robust extension
+		if d < spike {
+			weights[d-1] += r / float64(d*k)
+		} else if d == spike {
+			weights[d-1] += r * math.Log(r/solitonDelta) / float64(k)
+		}
+
+		sum += weights[d-1]
+	}
+
+	cdf := make([]float64, k)
+	acc := 0.0
+
+	for i, w := range weights {
+		acc += w / sum
+		cdf[i] = acc
+	}
+
+	return cdf
+}
+
+// neighbours returns the indexes of the blocks combined into the symbol with the given ID.
+func neighbours(id uint32, k int, cdf []float64) []int {
+	if int64(id) < int64(k) {
+		return []int{int(id)}
+	}
+
+	rng := newPRNG(uint64(id))
+
+	degree := 1
+	p := rng.float64()
+
+	for degree < k && p > cdf[degree-1] {
+		degree++
+	}
+
+	// Partial Fisher-Yates shuffle to pick degree distinct blocks.
+	picked := make(map[int]int, degree*2)
+	out := make([]int, degree)
+
+	for i := 0; i < degree; i++ {
+		j := i + rng.intn(k-i)
+
+		vi, ok := picked[i]
+		if !ok {
+			vi = i
+		}
+
+		vj, ok := picked[j]
+		if !ok {
+			vj = j
+		}
+
+		picked[i], picked[j] = vj, vi
+		out[i] = vj
+	}
+
+	return out
+}
+
+// prng is a splitmix64 generator. It is used instead of math/rand so the
+// symbol layout is fixed by this package and not by the Go release.
+type prng struct {
+	state uint64
+}
+
+func newPRNG(seed uint64) *prng {
+	return &prng{state: seed}
+}
+
+func (p *prng) next() uint64 {
+	p.state += 0x9e3779b97f4a7c15
+	z := p.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+
+	return z ^ (z >> 31)
+}
+
+func (p *prng) float64() float64 {
+	return float64(p.next()>>11) / (1 << 53)
+}
+
+func (p *prng) intn(n int) int {
+	return int(p.next() % uint64(n))
+}
@@ -0,0 +1,89 @@
+// Package gf256 implements arithmetic in the Galois field GF(2^8) with the
+// reducing polynomial x^8 + x^4 + x^3 + x^2 + 1 (0x11d) and the generator 2,
+// as used by the Reed-Solomon codes of QR codes.
+package gf256
+
+// Polynomial is the reducing polynomial of the field.
+const Polynomial = 0x11d
+
+var (
+	expTable [510]byte
+	logTable [256]int
+)
+
+func init() {
+	x := 1
+
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		expTable[i+255] = byte(x)
+		logTable[x] = i
+
+		x <<= 1
+
+		if x&0x100 != 0 {
+			x ^= Polynomial
+		}
+	}
+}
+
+// Exp returns the generator 2 raised to the power of n, n may be negative.
+func Exp(n int) byte {
+	n %= 255
+
+	if n < 0 {
+		n += 255
+	}
+
+	return expTable[n]
+}
+
+// Log returns the logarithm of x to the base of the generator 2.
+// The logarithm of 0 is undefined and the function panics for it.
+func Log(x byte) int {
+	if x == 0 {
+		panic("gf256: logarithm of zero")
+	}
+
+	return logTable[x]
+}
+
+// Mul returns the product of a and b.
+func Mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return expTable[logTable[a]+logTable[b]]
+}
+
+// Div returns the quotient of a and b. It panics if b is zero.
+func Div(a, b byte) byte {
+	if b == 0 {
+		panic("gf256: division by zero")
+	}
+
+	if a == 0 {
+		return 0
+	}
+
+	return expTable[logTable[a]+255-logTable[b]]
+}
+
+// Inv returns the multiplicative inverse of x. It panics if x is zero.
+func Inv(x byte) byte {
+	return Div(1, x)
+}
+
+// Pow returns x raised to the power of n, n may be negative for non-zero x.
+func Pow(x byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+
+	if x == 0 {
+		return 0
+	}
+
+	return Exp(logTable[x] * n)
+}
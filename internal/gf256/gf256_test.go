@@ -0,0 +1,56 @@
+package gf256
+
+import "testing"
+
+func TestExpLogRoundTrip(t *testing.T) {
+	for x := 1; x < 256; x++ {
+		if got := Exp(Log(byte(x))); got != byte(x) {
+			t.Errorf("Expected Exp(Log(%d)) to be %d, got %d", x, x, got)
+		}
+	}
+}
+
+func TestMulMatchesCarrylessMultiplication(t *testing.T) {
+	for a := 0; a < 256; a++ {
+		for b := 0; b < 256; b++ {
+			// reference: shift-and-add multiplication with reduction
+			x, y, p := a, b, 0
+
+			for y > 0 {
+				if y&1 != 0 {
+					p ^= x
+				}
+
+				x <<= 1
+
+				if x&0x100 != 0 {
+					x ^= Polynomial
+				}
+
+				y >>= 1
+			}
+
+			if got := Mul(byte(a), byte(b)); got != byte(p) {
+				t.Fatalf("Expected %d * %d to be %d, got %d", a, b, p, got)
+			}
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	for x := 1; x < 256; x++ {
+		if got := Mul(byte(x), Inv(byte(x))); got != 1 {
+			t.Errorf("Expected %d * Inv(%d) to be 1, got %d", x, x, got)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	if got := Pow(2, 8); got != 0x1d {
+		t.Errorf("Expected 2^8 to be 0x1d, got %#x", got)
+	}
+
+	if got := Mul(Pow(3, -1), 3); got != 1 {
+		t.Errorf("Expected 3^-1 * 3 to be 1, got %d", got)
+	}
+}
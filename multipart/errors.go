@@ -0,0 +1,31 @@
+package multipart
+
+import "errors"
+
+// ErrEmptyInput means that there is no data to split.
+var ErrEmptyInput = errors.New("empty input value")
+
+// ErrPartLengthTooSmall means the requested part length can not hold the
+// header and at least one pair of data bytes.
+var ErrPartLengthTooSmall = errors.New("part length too small")
+
+// ErrTooManyParts means the data would need more parts than the header can count.
+var ErrTooManyParts = errors.New("too many parts")
+
+// ErrInvalidHeader means a part is too short or carries header values that
+// can not be valid, like an index outside of the total.
+var ErrInvalidHeader = errors.New("invalid part header")
+
+// ErrChecksumMismatch means the checksum of a part does not match its content.
+var ErrChecksumMismatch = errors.New("part checksum mismatch")
+
+// ErrMessageMismatch means a part belongs to a different message than the
+// parts added before, by its message ID or total part count.
+var ErrMessageMismatch = errors.New("part belongs to a different message")
+
+// ErrConflictingPart means a part with the same index but different content
+// has been added before.
+var ErrConflictingPart = errors.New("conflicting part for the same index")
+
+// ErrIncomplete means not all parts of the message have been added yet.
+var ErrIncomplete = errors.New("message is incomplete")
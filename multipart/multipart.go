@@ -0,0 +1,83 @@
+// Package multipart splits data that does not fit into a single QR code into
+// several base 45 encoded parts and reassembles them in any order.
+//
+// Every part starts with a 12 byte header, followed by a chunk of the data:
+//
+//	message ID (4) | index (2) | total (2) | CRC-32 of the preceding fields and the chunk (4)
+//
+// All values are big endian. The header and all chunks but the last have an even
+// length, so every part is an independent base 45 string built from full triplets.
+package multipart
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+// HeaderSize is the size of the binary header of each part in bytes.
+const HeaderSize = 12
+
+// Split splits the data into base 45 encoded parts of at most maxLength
+// characters each, using a random message ID.
+func Split(in []byte, maxLength int) ([][]byte, error) {
+	id := make([]byte, 4)
+
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	return SplitWithID(in, binary.BigEndian.Uint32(id), maxLength)
+}
+
+// SplitWithID works like Split with the given message ID.
+func SplitWithID(in []byte, id uint32, maxLength int) ([][]byte, error) {
+	if len(in) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	// Three characters carry two bytes, so this keeps the chunk size even.
+	chunkSize := maxLength/3*2 - HeaderSize
+
+	if chunkSize < 2 {
+		return nil, ErrPartLengthTooSmall
+	}
+
+	total := (len(in) + chunkSize - 1) / chunkSize
+
+	if total > math.MaxUint16 {
+		return nil, ErrTooManyParts
+	}
+
+	parts := make([][]byte, 0, total)
+
+	for index := 0; index < total; index++ {
+		start := index * chunkSize
+		end := start + chunkSize
+
+		if end > len(in) {
+			end = len(in)
+		}
+
+		part := make([]byte, HeaderSize, HeaderSize+end-start)
+		binary.BigEndian.PutUint32(part[0:4], id)
+		binary.BigEndian.PutUint16(part[4:6], uint16(index))
+		binary.BigEndian.PutUint16(part[6:8], uint16(total))
+		part = append(part, in[start:end]...)
+		binary.BigEndian.PutUint32(part[8:12], checksum(part))
+
+		parts = append(parts, base45.Encode(part))
+	}
+
+	return parts, nil
+}
+
+// checksum calculates the CRC-32 of the given raw part, skipping the checksum field.
+func checksum(part []byte) uint32 {
+	sum := crc32.ChecksumIEEE(part[0:8])
+
+	return crc32.Update(sum, crc32.IEEETable, part[HeaderSize:])
+}
@@ -0,0 +1,57 @@
+package multipart
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestSplitPartLength(t *testing.T) {
+	data := make([]byte, 1000)
+	rand.Read(data)
+
+	parts, err := SplitWithID(data, 42, 100)
+
+	if err != nil {
+		t.Fatalf("Expected parts, got error \"%s\"", err)
+	}
+
+	for i, part := range parts {
+		if len(part) > 100 {
+			t.Errorf("Expected part %d to have at most 100 characters, got %d", i, len(part))
+		}
+
+		if i < len(parts)-1 && len(part)%3 != 0 {
+			t.Errorf("Expected part %d to consist of full triplets, got %d characters", i, len(part))
+		}
+
+		if _, err := base45.Decode(part); err != nil {
+			t.Errorf("Expected part %d to be valid base 45, got error \"%s\"", i, err)
+		}
+	}
+}
+
+func TestSplitEmptyInput(t *testing.T) {
+	_, err := Split([]byte{}, 100)
+
+	if err != ErrEmptyInput {
+		t.Errorf("Expected ErrEmptyInput, got \"%v\"", err)
+	}
+}
+
+func TestSplitPartLengthTooSmall(t *testing.T) {
+	_, err := Split([]byte("Hello!!"), 20)
+
+	if err != ErrPartLengthTooSmall {
+		t.Errorf("Expected ErrPartLengthTooSmall, got \"%v\"", err)
+	}
+}
+
+func TestSplitTooManyParts(t *testing.T) {
+	_, err := Split(make([]byte, 2*65536), 21)
+
+	if err != ErrTooManyParts {
+		t.Errorf("Expected ErrTooManyParts, got \"%v\"", err)
+	}
+}
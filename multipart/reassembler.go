@@ -0,0 +1,116 @@
+package multipart
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+// Reassembler collects the parts of a single message in any order.
+// The zero value is not usable, use NewReassembler instead.
+type Reassembler struct {
+	id     uint32
+	total  int
+	chunks map[int][]byte
+}
+
+// NewReassembler returns an empty Reassembler.
+func NewReassembler() *Reassembler {
+	return &Reassembler{
+		chunks: make(map[int][]byte),
+	}
+}
+
+// Add decodes the given base 45 encoded part and adds it to the message.
+// The first added part determines the message ID and part count, parts of
+// other messages are rejected with ErrMessageMismatch. Duplicates of already
+// added parts are ignored. It reports whether the message is complete.
+func (r *Reassembler) Add(in []byte) (bool, error) {
+	part, err := base45.Decode(in)
+
+	if err != nil {
+		return r.Complete(), err
+	}
+
+	if len(part) < HeaderSize {
+		return r.Complete(), ErrInvalidHeader
+	}
+
+	id := binary.BigEndian.Uint32(part[0:4])
+	index := int(binary.BigEndian.Uint16(part[4:6]))
+	total := int(binary.BigEndian.Uint16(part[6:8]))
+
+	if total == 0 || index >= total {
+		return r.Complete(), ErrInvalidHeader
+	}
+
+	if binary.BigEndian.Uint32(part[8:12]) != checksum(part) {
+		return r.Complete(), ErrChecksumMismatch
+	}
+
+	if r.total == 0 {
+		r.id = id
+		r.total = total
+	} else if r.id != id || r.total != total {
+		return r.Complete(), ErrMessageMismatch
+	}
+
+	chunk := part[HeaderSize:]
+
+	if existing, ok := r.chunks[index]; ok {
+		if !bytes.Equal(existing, chunk) {
+			return r.Complete(), ErrConflictingPart
+		}
+
+		return r.Complete(), nil
+	}
+
+	r.chunks[index] = chunk
+
+	return r.Complete(), nil
+}
+
+// ID returns the message ID taken from the first added part.
+func (r *Reassembler) ID() uint32 {
+	return r.id
+}
+
+// Total returns the part count of the message, or 0 if no part was added yet.
+func (r *Reassembler) Total() int {
+	return r.total
+}
+
+// Complete reports whether all parts of the message have been added.
+func (r *Reassembler) Complete() bool {
+	return r.total > 0 && len(r.chunks) == r.total
+}
+
+// Missing returns the indexes of the parts that have not been added yet.
+func (r *Reassembler) Missing() []int {
+	missing := make([]int, 0, r.total-len(r.chunks))
+
+	for i := 0; i < r.total; i++ {
+		if _, ok := r.chunks[i]; !ok {
+			missing = append(missing, i)
+		}
+	}
+
+	return missing
+}
+
+// Bytes returns the reassembled message.
+// If parts are still missing, ErrIncomplete is returned.
+func (r *Reassembler) Bytes() ([]byte, error) {
+	if !r.Complete() {
+		return nil, ErrIncomplete
+	}
+
+	var out []byte
+
+	for i := 0; i < r.total; i++ {
+		out = append(out, r.chunks[i]...)
+	}
+
+	return out, nil
+}
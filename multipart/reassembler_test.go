@@ -0,0 +1,131 @@
+package multipart
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestReassembleInAnyOrder(t *testing.T) {
+	expected := make([]byte, 1001)
+	rand.Read(expected)
+
+	parts, _ := Split(expected, 90)
+	rand.Shuffle(len(parts), func(i, j int) { parts[i], parts[j] = parts[j], parts[i] })
+
+	r := NewReassembler()
+
+	for i, part := range parts {
+		complete, err := r.Add(part)
+
+		if err != nil {
+			t.Fatalf("Expected part to be added, got error \"%s\"", err)
+		}
+
+		if complete != (i == len(parts)-1) {
+			t.Errorf("Unexpected completion state %v after %d of %d parts", complete, i+1, len(parts))
+		}
+	}
+
+	got, err := r.Bytes()
+
+	if err != nil {
+		t.Fatalf("Expected message, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Reassembled message not equal to the original")
+	}
+}
+
+func TestReassembleDuplicates(t *testing.T) {
+	parts, _ := SplitWithID([]byte("Hello, multipart world!"), 7, 30)
+	r := NewReassembler()
+
+	r.Add(parts[0])
+
+	if _, err := r.Add(parts[0]); err != nil {
+		t.Errorf("Expected duplicate to be ignored, got error \"%s\"", err)
+	}
+
+	if got := r.Missing(); len(got) != len(parts)-1 || got[0] != 1 {
+		t.Errorf("Expected parts 1 to %d to be missing, got %v", len(parts)-1, got)
+	}
+}
+
+func TestReassembleIncomplete(t *testing.T) {
+	parts, _ := SplitWithID([]byte("Hello, multipart world!"), 7, 30)
+	r := NewReassembler()
+	r.Add(parts[1])
+
+	_, err := r.Bytes()
+
+	if err != ErrIncomplete {
+		t.Errorf("Expected ErrIncomplete, got \"%v\"", err)
+	}
+}
+
+func TestReassembleMessageMismatch(t *testing.T) {
+	a, _ := SplitWithID([]byte("Hello, multipart world!"), 1, 30)
+	b, _ := SplitWithID([]byte("Hello, multipart world!"), 2, 30)
+	r := NewReassembler()
+	r.Add(a[0])
+
+	_, err := r.Add(b[1])
+
+	if err != ErrMessageMismatch {
+		t.Errorf("Expected ErrMessageMismatch, got \"%v\"", err)
+	}
+}
+
+func TestReassembleConflictingPart(t *testing.T) {
+	a, _ := SplitWithID([]byte("Hello, multipart world!"), 1, 30)
+	b, _ := SplitWithID([]byte("Hello, multipart World!"), 1, 30)
+	r := NewReassembler()
+
+	for i := range a {
+		if bytes.Equal(a[i], b[i]) {
+			continue
+		}
+
+		r.Add(a[i])
+
+		if _, err := r.Add(b[i]); err != ErrConflictingPart {
+			t.Errorf("Expected ErrConflictingPart, got \"%v\"", err)
+		}
+
+		return
+	}
+
+	t.Fatalf("Expected the messages to differ in at least one part")
+}
+
+func TestReassembleChecksumMismatch(t *testing.T) {
+	parts, _ := SplitWithID([]byte("Hello, multipart world!"), 1, 30)
+	raw, _ := base45.Decode(parts[0])
+	raw[len(raw)-1] ^= 0x01
+
+	_, err := NewReassembler().Add(base45.Encode(raw))
+
+	if err != ErrChecksumMismatch {
+		t.Errorf("Expected ErrChecksumMismatch, got \"%v\"", err)
+	}
+}
+
+func TestReassembleInvalidHeader(t *testing.T) {
+	_, err := NewReassembler().Add(base45.Encode([]byte("short")))
+
+	if err != ErrInvalidHeader {
+		t.Errorf("Expected ErrInvalidHeader, got \"%v\"", err)
+	}
+}
+
+func TestReassembleInvalidBase45(t *testing.T) {
+	_, err := NewReassembler().Add([]byte("GGW"))
+
+	if err != base45.ErrInvalidEncodedDataOverflow {
+		t.Errorf("Expected ErrInvalidEncodedDataOverflow, got \"%v\"", err)
+	}
+}
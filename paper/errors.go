@@ -0,0 +1,15 @@
+package paper
+
+import "errors"
+
+// ErrEmptyInput means that there is no data to back up.
+var ErrEmptyInput = errors.New("empty input value")
+
+// ErrInvalidPayload means the restored payload carries unknown flags or is empty.
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// ErrPassphraseRequired means the payload is encrypted, but no passphrase was given.
+var ErrPassphraseRequired = errors.New("passphrase required")
+
+// ErrPageTooSmall means a single part with its text does not fit on a page.
+var ErrPageTooSmall = errors.New("part does not fit on a page")
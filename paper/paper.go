@@ -0,0 +1,252 @@
+// Package paper turns data into a paper backup of base 45 QR codes and
+// restores it from the printed text.
+//
+// The data is optionally compressed with the default dictionary of the
+// compression package and encrypted with a passphrase, then split into
+// multipart parts that each fit into a single QR code. Every part is protected
+// by Reed-Solomon parity, so typing mistakes and unreadable characters in the
+// printed text can be corrected on restore. The payload split into parts is:
+//
+//	flags (1) | data
+//
+// where bit 0 of the flags marks compressed and bit 1 encrypted data.
+//
+// Restoring from scanned images needs a QR code reader, which is out of scope
+// for this module. The text of the parts, whether typed by hand or read by an
+// external scanner, is restored with Decode.
+package paper
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/adrianrudnik/base45-go"
+	"github.com/adrianrudnik/base45-go/compression"
+	"github.com/adrianrudnik/base45-go/multipart"
+	"github.com/adrianrudnik/base45-go/passphrase"
+	"github.com/adrianrudnik/base45-go/qr"
+	"github.com/adrianrudnik/base45-go/reedsolomon"
+)
+
+const (
+	// DefaultParity is the number of Reed-Solomon parity bytes per block used
+	// if Options.Parity is zero.
+	DefaultParity = 16
+
+	// DefaultVersion is the QR code version used if Options.Version is zero.
+	DefaultVersion = 10
+)
+
+const (
+	flagCompressed = 1 << iota
+	flagEncrypted
+)
+
+// Options configure a paper backup. The zero value creates uncompressed,
+// unencrypted parts with the defaults above at error correction level L.
+type Options struct {
+	// Compress compresses the data before it is split. Data beyond
+	// compression.MaxDecompressedSize is rejected, as it could not be
+	// decompressed on restore.
+	Compress bool
+
+	// Passphrase encrypts the data if not empty and is required to restore it.
+	Passphrase []byte
+
+	// Iterations is the PBKDF2 iteration count for the passphrase, zero uses
+	// passphrase.DefaultIterations.
+	Iterations int
+
+	// Parity is the number of Reed-Solomon parity bytes per block of a part.
+	// It has to match on restore.
+	Parity int
+
+	// Level is the error correction level of the QR codes.
+	Level qr.Level
+
+	// Version is the QR code version every part is sized for.
+	Version int
+}
+
+func (o Options) parity() int {
+	if o.Parity == 0 {
+		return DefaultParity
+	}
+
+	return o.Parity
+}
+
+func (o Options) version() int {
+	if o.Version == 0 {
+		return DefaultVersion
+	}
+
+	return o.Version
+}
+
+func (o Options) iterations() int {
+	if o.Iterations == 0 {
+		return passphrase.DefaultIterations
+	}
+
+	return o.Iterations
+}
+
+// Encode prepares the given data for a paper backup and returns the base 45
+// text of each part. Every part fits into a QR code of the configured version
+// and level.
+func Encode(in []byte, opts Options) ([][]byte, error) {
+	if len(in) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	parity := opts.parity()
+
+	if parity < 1 || parity >= reedsolomon.MaxCodewordLength {
+		return nil, reedsolomon.ErrInvalidParity
+	}
+
+	capacity, err := qr.AlphanumericCapacity(opts.version(), opts.Level)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var flags byte
+	data := in
+
+	if opts.Compress {
+		if len(data) > compression.MaxDecompressedSize {
+			return nil, compression.ErrOutputTooLarge
+		}
+
+		flags |= flagCompressed
+
+		if data, err = compression.Compress(data, compression.DefaultDictionary); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.Passphrase) > 0 {
+		flags |= flagEncrypted
+
+		enc, err := passphrase.EncryptWithIterations(data, opts.Passphrase, opts.iterations())
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Our own base 45 output always decodes.
+		data, _ = base45.Decode(enc)
+	}
+
+	// Reserve the parity of every Reed-Solomon block within the bytes a
+	// QR code can hold, the multipart length is given in characters.
+	size := capacity / 3 * 2
+	blocks := (size + reedsolomon.MaxCodewordLength - 1) / reedsolomon.MaxCodewordLength
+	maxLength := (size - blocks*parity) / 2 * 3
+
+	parts, err := multipart.Split(append([]byte{flags}, data...), maxLength)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([][]byte, len(parts))
+
+	for i, part := range parts {
+		raw, _ := base45.Decode(part)
+
+		if out[i], err = reedsolomon.Encode(raw, parity); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Decode corrects the base 45 text of the given parts, reassembles them in any
+// order and returns the original data. The options have to match the parity
+// and passphrase used by Encode.
+func Decode(parts [][]byte, opts Options) ([]byte, error) {
+	r := multipart.NewReassembler()
+
+	for _, part := range parts {
+		raw, err := reedsolomon.Decode(part, opts.parity())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := r.Add(base45.Encode(raw)); err != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := r.Bytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) < 1 || payload[0]&^(flagCompressed|flagEncrypted) != 0 {
+		return nil, ErrInvalidPayload
+	}
+
+	flags, data := payload[0], payload[1:]
+
+	if flags&flagEncrypted != 0 {
+		if len(opts.Passphrase) == 0 {
+			return nil, ErrPassphraseRequired
+		}
+
+		if data, err = passphrase.Decrypt(base45.Encode(data), opts.Passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	if flags&flagCompressed != 0 {
+		if data, err = compression.Decompress(data, compression.DefaultDictionary); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// ParseText reads typed parts, separated by blank lines. The lines of a part
+// are joined as they are, so spaces at the end of a line are kept, and lower
+// case letters are converted to upper case.
+func ParseText(r io.Reader) ([][]byte, error) {
+	var parts [][]byte
+	var part []byte
+
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+
+	for s.Scan() {
+		line := bytes.TrimSuffix(s.Bytes(), []byte("\r"))
+
+		if len(line) == 0 {
+			if len(part) > 0 {
+				parts = append(parts, part)
+				part = nil
+			}
+
+			continue
+		}
+
+		part = append(part, bytes.ToUpper(line)...)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(part) > 0 {
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
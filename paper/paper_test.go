@@ -0,0 +1,131 @@
+package paper
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go/passphrase"
+	"github.com/adrianrudnik/base45-go/qr"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	expected := bytes.Repeat([]byte("A paper backup of base 45 QR codes. "), 100)
+
+	opts := Options{
+		Compress:   true,
+		Passphrase: []byte("correct horse battery staple"),
+		Iterations: passphrase.MinIterations,
+		Level:      qr.M,
+		Version:    5,
+	}
+
+	parts, err := Encode(expected, opts)
+
+	if err != nil {
+		t.Fatalf("Expected parts, got error \"%s\"", err)
+	}
+
+	if len(parts) < 2 {
+		t.Fatalf("Expected several parts, got %d", len(parts))
+	}
+
+	capacity, _ := qr.AlphanumericCapacity(5, qr.M)
+
+	for i, part := range parts {
+		if len(part) > capacity {
+			t.Errorf("Expected part %d to fit into %d characters, got %d", i, capacity, len(part))
+		}
+	}
+
+	// Reverse the order and mistype a character in every part.
+	reversed := make([][]byte, len(parts))
+
+	for i, part := range parts {
+		typed := append([]byte{}, part...)
+		typed[len(typed)/2] = 'Q'
+
+		if typed[len(typed)/2] == part[len(part)/2] {
+			typed[len(typed)/2] = 'W'
+		}
+
+		reversed[len(parts)-1-i] = typed
+	}
+
+	actual, err := Decode(reversed, opts)
+
+	if err != nil {
+		t.Fatalf("Expected restored data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("Expected restored data to match the input")
+	}
+}
+
+func TestDecodePassphraseRequired(t *testing.T) {
+	parts, _ := Encode([]byte("Hello!!"), Options{
+		Passphrase: []byte("secret"),
+		Iterations: passphrase.MinIterations,
+	})
+
+	_, err := Decode(parts, Options{})
+
+	if err != ErrPassphraseRequired {
+		t.Errorf("Expected ErrPassphraseRequired, got \"%v\"", err)
+	}
+}
+
+func TestEncodeEmptyInput(t *testing.T) {
+	_, err := Encode(nil, Options{})
+
+	if err != ErrEmptyInput {
+		t.Errorf("Expected ErrEmptyInput, got \"%v\"", err)
+	}
+}
+
+func TestParseText(t *testing.T) {
+	in := "abc\r\nDE \nF\n\n\nGHI\n"
+
+	parts, err := ParseText(strings.NewReader(in))
+
+	if err != nil {
+		t.Fatalf("Expected parts, got error \"%s\"", err)
+	}
+
+	if len(parts) != 2 || string(parts[0]) != "ABCDE F" || string(parts[1]) != "GHI" {
+		t.Errorf("Expected [\"ABCDE F\" \"GHI\"], got %q", parts)
+	}
+}
+
+func TestRender(t *testing.T) {
+	data := make([]byte, 6000)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	for _, opts := range []Options{{Version: 2, Parity: 4}, {}, {Version: 40}, {Version: 40, Level: qr.H}} {
+		parts, err := Encode(data, opts)
+
+		if err != nil {
+			t.Fatalf("Expected parts for version %d, got error \"%s\"", opts.version(), err)
+		}
+
+		pages, err := Render(parts, opts)
+
+		if err != nil {
+			t.Fatalf("Expected pages for version %d, got error \"%s\"", opts.version(), err)
+		}
+
+		if len(pages) == 0 || len(pages) > len(parts) {
+			t.Errorf("Expected between 1 and %d pages, got %d", len(parts), len(pages))
+		}
+
+		// The top left module of the first symbol belongs to a finder pattern.
+		x := margin + quietZone
+		y := margin + 3*lineHeight + quietZone
+
+		if pages[0].ColorIndexAt(x, y) != 1 {
+			t.Errorf("Expected dark finder module at %d,%d on version %d", x, y, opts.version())
+		}
+	}
+}
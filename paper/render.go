@@ -0,0 +1,136 @@
+package paper
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/adrianrudnik/base45-go"
+	"github.com/adrianrudnik/base45-go/qr"
+)
+
+const (
+	// PageWidth and PageHeight are the size of a rendered page in pixels,
+	// an A4 sheet at 150 dpi.
+	PageWidth  = 1240
+	PageHeight = 1754
+)
+
+const (
+	margin       = 48
+	moduleSize   = 4
+	quietZone    = 4 * moduleSize
+	glyphScale   = 2
+	glyphAdvance = 6 * glyphScale
+	lineHeight   = 9 * glyphScale
+	partGap      = 2 * lineHeight
+)
+
+// palette keeps the pages at one bit per pixel, white being the zero value.
+var palette = color.Palette{color.White, color.Black}
+
+// Render lays out the parts returned by Encode on printable pages. Every part
+// is drawn as a QR code, followed by its base 45 text for manual re-entry.
+// Each page is headed by its number and the parity needed to restore it.
+// Use image/png to write the pages.
+func Render(parts [][]byte, opts Options) ([]*image.Paletted, error) {
+	columns := (PageWidth - 2*margin) / glyphAdvance
+	top := margin + 2*lineHeight
+
+	var pages []*image.Paletted
+	var page *image.Paletted
+	y := 0
+
+	for i, text := range parts {
+		symbol, err := qr.EncodeVersion(text, opts.Level, opts.version())
+
+		if err != nil {
+			return nil, err
+		}
+
+		lines := (len(text) + columns - 1) / columns
+		symbolSize := symbol.Size*moduleSize + 2*quietZone
+		height := lineHeight + symbolSize + lines*lineHeight
+
+		if top+height > PageHeight-margin {
+			return nil, ErrPageTooSmall
+		}
+
+		if page == nil || y+height > PageHeight-margin {
+			page = image.NewPaletted(image.Rect(0, 0, PageWidth, PageHeight), palette)
+			pages = append(pages, page)
+			y = top
+		}
+
+		drawText(page, margin, y, []byte(fmt.Sprintf("PART %d/%d", i+1, len(parts))), false)
+		y += lineHeight
+
+		drawSymbol(page, margin, y, symbol)
+		y += symbolSize
+
+		for start := 0; start < len(text); start += columns {
+			end := start + columns
+
+			if end > len(text) {
+				end = len(text)
+			}
+
+			drawText(page, margin, y, text[start:end], true)
+			y += lineHeight
+		}
+
+		y += partGap
+	}
+
+	for i, page := range pages {
+		header := fmt.Sprintf("PAGE %d/%d   PARITY %d", i+1, len(pages), opts.parity())
+		drawText(page, margin, margin, []byte(header), false)
+	}
+
+	return pages, nil
+}
+
+// drawSymbol draws the symbol with its quiet zone at the given position.
+func drawSymbol(img *image.Paletted, x, y int, symbol *qr.Symbol) {
+	for row, modules := range symbol.Modules {
+		for col, dark := range modules {
+			if dark {
+				fill(img, x+quietZone+col*moduleSize, y+quietZone+row*moduleSize, moduleSize)
+			}
+		}
+	}
+}
+
+// drawText draws a single line of base 45 characters at the given position.
+// Unless visibleSpace is set, spaces are left blank.
+func drawText(img *image.Paletted, x, y int, text []byte, visibleSpace bool) {
+	for i, c := range text {
+		if c == ' ' && !visibleSpace {
+			continue
+		}
+
+		g := bytes.IndexByte(base45.Alphabet, c)
+
+		if g < 0 {
+			continue
+		}
+
+		for row, bits := range glyphs[g] {
+			for col := 0; col < 5; col++ {
+				if bits&(0x10>>uint(col)) != 0 {
+					fill(img, x+i*glyphAdvance+col*glyphScale, y+row*glyphScale, glyphScale)
+				}
+			}
+		}
+	}
+}
+
+// fill paints a dark square of the given size.
+func fill(img *image.Paletted, x, y, size int) {
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			img.SetColorIndex(x+dx, y+dy, 1)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package passphrase
+
+import "errors"
+
+// ErrUnsupportedVersion means the payload header carries a format version
+// this package does not know how to decrypt.
+var ErrUnsupportedVersion = errors.New("unsupported payload version")
+
+// ErrTruncatedData means the decoded payload is shorter than its header and
+// authentication tag require.
+var ErrTruncatedData = errors.New("truncated encrypted payload")
+
+// ErrInvalidIterations means the key derivation iteration count is outside
+// the accepted range, either on encryption or as read from a payload header.
+var ErrInvalidIterations = errors.New("invalid key derivation iteration count")
+
+// ErrAuthenticationFailed means the payload could not be decrypted, because
+// either the passphrase is wrong or the payload has been tampered with.
+var ErrAuthenticationFailed = errors.New("wrong passphrase or tampered payload")
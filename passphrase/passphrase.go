@@ -0,0 +1,145 @@
+// Package passphrase protects small payloads with a passphrase before they are
+// base 45 encoded, e.g. to share secrets via printed QR codes.
+//
+// The key is derived with PBKDF2-HMAC-SHA256 and the data is encrypted with
+// AES-256-GCM. The payload layout of version 1 is:
+//
+//	version (1) | iterations (4, big endian) | salt (16) | nonce (12) | ciphertext and tag
+//
+// The whole header is authenticated as additional data, so any change to it
+// is detected on decryption.
+package passphrase
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+const (
+	// Version is the payload format version written by Encrypt.
+	Version = 1
+
+	// DefaultIterations is the PBKDF2 iteration count used by Encrypt.
+	DefaultIterations = 600000
+
+	// MinIterations and MaxIterations bound the accepted iteration counts, the
+	// upper bound keeps crafted payloads from stalling the decryption. Use
+	// DecryptWithMaxIterations to accept less.
+	MinIterations = 1000
+	MaxIterations = 2 * DefaultIterations
+)
+
+const (
+	keySize    = 32
+	saltSize   = 16
+	nonceSize  = 12
+	tagSize    = 16
+	headerSize = 1 + 4 + saltSize + nonceSize
+)
+
+// Encrypt encrypts the given bytes with a key derived from the passphrase and
+// returns the base 45 encoded payload, using DefaultIterations.
+func Encrypt(in, passphrase []byte) ([]byte, error) {
+	return EncryptWithIterations(in, passphrase, DefaultIterations)
+}
+
+// EncryptWithIterations works like Encrypt with a custom PBKDF2 iteration count.
+func EncryptWithIterations(in, passphrase []byte, iterations int) ([]byte, error) {
+	if iterations < MinIterations || iterations > MaxIterations {
+		return nil, ErrInvalidIterations
+	}
+
+	header := make([]byte, headerSize)
+	header[0] = Version
+	binary.BigEndian.PutUint32(header[1:5], uint32(iterations))
+
+	salt := header[5 : 5+saltSize]
+	nonce := header[5+saltSize:]
+
+	if _, err := rand.Read(header[5:]); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passphrase, salt, iterations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := aead.Seal(header, nonce, in, header)
+
+	return base45.Encode(out), nil
+}
+
+// Decrypt decodes the given base 45 payload and decrypts it with the passphrase.
+// Errors of the base 45 decoding are returned as they are, everything that
+// fails after that is reported with the errors of this package.
+func Decrypt(in, passphrase []byte) ([]byte, error) {
+	return DecryptWithMaxIterations(in, passphrase, MaxIterations)
+}
+
+// DecryptWithMaxIterations works like Decrypt, but rejects payloads that claim
+// more than maxIterations PBKDF2 iterations with ErrInvalidIterations, before
+// any key derivation work is done. Values above MaxIterations are capped.
+func DecryptWithMaxIterations(in, passphrase []byte, maxIterations int) ([]byte, error) {
+	if maxIterations > MaxIterations {
+		maxIterations = MaxIterations
+	}
+
+	data, err := base45.Decode(in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 1 {
+		return nil, ErrTruncatedData
+	}
+
+	if data[0] != Version {
+		return nil, ErrUnsupportedVersion
+	}
+
+	if len(data) < headerSize+tagSize {
+		return nil, ErrTruncatedData
+	}
+
+	iterations := binary.BigEndian.Uint32(data[1:5])
+
+	if iterations < MinIterations || int64(iterations) > int64(maxIterations) {
+		return nil, ErrInvalidIterations
+	}
+
+	header := data[:headerSize]
+	salt := header[5 : 5+saltSize]
+	nonce := header[5+saltSize:]
+
+	aead, err := newAEAD(passphrase, salt, int(iterations))
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := aead.Open(nil, nonce, data[headerSize:], header)
+
+	if err != nil {
+		return nil, ErrAuthenticationFailed
+	}
+
+	return out, nil
+}
+
+// newAEAD returns the AES-256-GCM cipher for the key derived from the passphrase.
+func newAEAD(passphrase, salt []byte, iterations int) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveKey(passphrase, salt, iterations, keySize))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,145 @@
+package passphrase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+// testIterations keeps the tests fast, real payloads should use DefaultIterations.
+const testIterations = MinIterations
+
+func TestEncryptDecrypt(t *testing.T) {
+	expected := []byte("recovery key: 1234-5678")
+
+	enc, err := EncryptWithIterations(expected, []byte("secret"), testIterations)
+
+	if err != nil {
+		t.Fatalf("Expected encrypted payload, got error \"%s\"", err)
+	}
+
+	got, err := Decrypt(enc, []byte("secret"))
+
+	if err != nil {
+		t.Fatalf("Expected decrypted payload, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected \"%s\", got \"%s\"", expected, got)
+	}
+}
+
+func TestEncryptUsesFreshSaltAndNonce(t *testing.T) {
+	a, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+	b, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+
+	if bytes.Equal(a, b) {
+		t.Errorf("Expected different payloads for repeated encryption")
+	}
+}
+
+func TestDecryptWrongPassphrase(t *testing.T) {
+	enc, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+
+	_, err := Decrypt(enc, []byte("guess"))
+
+	if err != ErrAuthenticationFailed {
+		t.Errorf("Expected ErrAuthenticationFailed, got \"%v\"", err)
+	}
+}
+
+func TestDecryptTamperedPayload(t *testing.T) {
+	enc, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+	raw, _ := base45.Decode(enc)
+
+	// flip a bit in the salt, the ciphertext and the tag
+	for _, pos := range []int{6, headerSize, len(raw) - 1} {
+		tampered := append([]byte{}, raw...)
+		tampered[pos] ^= 0x01
+
+		_, err := Decrypt(base45.Encode(tampered), []byte("secret"))
+
+		if err != ErrAuthenticationFailed {
+			t.Errorf("Expected ErrAuthenticationFailed for tampered byte %d, got \"%v\"", pos, err)
+		}
+	}
+}
+
+func TestDecryptTruncatedPayload(t *testing.T) {
+	enc, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+	raw, _ := base45.Decode(enc)
+
+	_, err := Decrypt(base45.Encode(raw[:headerSize+tagSize-1]), []byte("secret"))
+
+	if err != ErrTruncatedData {
+		t.Errorf("Expected ErrTruncatedData, got \"%v\"", err)
+	}
+}
+
+func TestDecryptUnsupportedVersion(t *testing.T) {
+	enc, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+	raw, _ := base45.Decode(enc)
+	raw[0] = Version + 1
+
+	_, err := Decrypt(base45.Encode(raw), []byte("secret"))
+
+	if err != ErrUnsupportedVersion {
+		t.Errorf("Expected ErrUnsupportedVersion, got \"%v\"", err)
+	}
+}
+
+func TestDecryptInvalidIterations(t *testing.T) {
+	enc, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+	raw, _ := base45.Decode(enc)
+	raw[1] = 0xff
+
+	_, err := Decrypt(base45.Encode(raw), []byte("secret"))
+
+	if err != ErrInvalidIterations {
+		t.Errorf("Expected ErrInvalidIterations, got \"%v\"", err)
+	}
+}
+
+func TestEncryptInvalidIterations(t *testing.T) {
+	_, err := EncryptWithIterations([]byte("data"), []byte("secret"), MinIterations-1)
+
+	if err != ErrInvalidIterations {
+		t.Errorf("Expected ErrInvalidIterations, got \"%v\"", err)
+	}
+}
+
+func TestDecryptInvalidBase45(t *testing.T) {
+	_, err := Decrypt([]byte("GGW"), []byte("secret"))
+
+	if err != base45.ErrInvalidEncodedDataOverflow {
+		t.Errorf("Expected ErrInvalidEncodedDataOverflow, got \"%v\"", err)
+	}
+}
+
+func TestDecryptAboveMaxIterations(t *testing.T) {
+	enc, _ := EncryptWithIterations([]byte("data"), []byte("secret"), testIterations)
+	raw, _ := base45.Decode(enc)
+	binary.BigEndian.PutUint32(raw[1:5], MaxIterations+1)
+
+	_, err := Decrypt(base45.Encode(raw), []byte("secret"))
+
+	if err != ErrInvalidIterations {
+		t.Errorf("Expected ErrInvalidIterations, got \"%v\"", err)
+	}
+}
+
+func TestDecryptWithMaxIterations(t *testing.T) {
+	enc, _ := EncryptWithIterations([]byte("data"), []byte("secret"), 2*testIterations)
+
+	if _, err := DecryptWithMaxIterations(enc, []byte("secret"), testIterations); err != ErrInvalidIterations {
+		t.Errorf("Expected ErrInvalidIterations, got \"%v\"", err)
+	}
+
+	got, err := DecryptWithMaxIterations(enc, []byte("secret"), 2*testIterations)
+
+	if err != nil || !bytes.Equal(got, []byte("data")) {
+		t.Errorf("Expected decrypted payload, got error \"%v\"", err)
+	}
+}
@@ -0,0 +1,45 @@
+package passphrase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// deriveKey derives a key of keyLen bytes with PBKDF2-HMAC-SHA256 as described in
+// https://datatracker.ietf.org/doc/rfc8018/ chapter 5.2.
+func deriveKey(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	out := make([]byte, 0, blocks*hashLen)
+	counter := make([]byte, 4)
+	u := make([]byte, hashLen)
+	t := make([]byte, hashLen)
+
+	for block := 1; block <= blocks; block++ {
+		// U_1 = PRF(P, S || INT(i))
+		binary.BigEndian.PutUint32(counter, uint32(block))
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter)
+		u = prf.Sum(u[:0])
+		copy(t, u)
+
+		// U_c = PRF(P, U_{c-1}), T_i = U_1 ^ U_2 ^ ... ^ U_c
+		for n := 2; n <= iterations; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+
+			for i := range t {
+				t[i] ^= u[i]
+			}
+		}
+
+		out = append(out, t...)
+	}
+
+	return out[:keyLen]
+}
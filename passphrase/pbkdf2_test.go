@@ -0,0 +1,37 @@
+package passphrase
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// Test vectors from https://datatracker.ietf.org/doc/rfc7914/ chapter 11.
+var pbkdf2Vectors = []struct {
+	password   string
+	salt       string
+	iterations int
+	expected   string
+}{
+	{
+		"passwd", "salt", 1,
+		"55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+			"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783",
+	},
+	{
+		"Password", "NaCl", 80000,
+		"4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56" +
+			"a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d",
+	},
+}
+
+func TestDeriveKeyWithRfcVectors(t *testing.T) {
+	for _, entry := range pbkdf2Vectors {
+		expected, _ := hex.DecodeString(entry.expected)
+		got := deriveKey([]byte(entry.password), []byte(entry.salt), entry.iterations, len(expected))
+
+		if !bytes.Equal(got, expected) {
+			t.Errorf("Unexpected key for \"%s\", expected %x, got %x", entry.password, expected, got)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package qr
+
+import "errors"
+
+// ErrInvalidLevel means the error correction level is not one of L, M, Q or H.
+var ErrInvalidLevel = errors.New("invalid error correction level")
+
+// ErrInvalidVersion means the version is outside of the range 1 to 40.
+var ErrInvalidVersion = errors.New("invalid version")
+
+// ErrDataTooLong means the data does not fit into the requested version, or
+// into version 40 if the version is chosen automatically.
+var ErrDataTooLong = errors.New("data too long for the symbol")
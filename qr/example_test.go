@@ -0,0 +1,12 @@
+package qr
+
+import (
+	"fmt"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func ExampleEncode() {
+	symbol, _ := Encode(base45.Encode([]byte("Hello!!")), M)
+	fmt.Printf("Version %d-%s with %d modules per side\n%s", symbol.Version, symbol.Level, symbol.Size, symbol)
+}
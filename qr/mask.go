@@ -0,0 +1,124 @@
+package qr
+
+// masked reports whether the given data mask pattern inverts the module at
+// column x and row y, see [1] Table 10.
+func masked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// applyMask inverts all non-function modules selected by the mask pattern.
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if !m.function[y][x] && masked(mask, x, y) {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// Penalty weights of [1] Chapter 7.8.3.1.
+const (
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+// penalty rates the current module matrix, lower is better.
+func (m *matrix) penalty() int {
+	result := 0
+
+	// Feature 1 and 3 on rows, then on columns.
+	for _, vertical := range []bool{false, true} {
+		get := func(i, j int) bool {
+			if vertical {
+				return m.modules[j][i]
+			}
+
+			return m.modules[i][j]
+		}
+
+		for i := 0; i < m.size; i++ {
+			run := 1
+
+			for j := 1; j <= m.size; j++ {
+				if j < m.size && get(i, j) == get(i, j-1) {
+					run++
+					continue
+				}
+
+				// adjacent modules in a row or column in the same color
+				if run >= 5 {
+					result += penaltyN1 + run - 5
+				}
+
+				run = 1
+			}
+
+			// 1:1:3:1:1 finder-like pattern with four light modules on one side
+			for j := 0; j+7 <= m.size; j++ {
+				if get(i, j) && !get(i, j+1) && get(i, j+2) && get(i, j+3) && get(i, j+4) && !get(i, j+5) && get(i, j+6) &&
+					(m.lightRun(get, i, j-4, j) || m.lightRun(get, i, j+7, j+11)) {
+					result += penaltyN3
+				}
+			}
+		}
+	}
+
+	// Feature 2: blocks of 2x2 modules in the same color.
+	for y := 0; y < m.size-1; y++ {
+		for x := 0; x < m.size-1; x++ {
+			c := m.modules[y][x]
+
+			if c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+				result += penaltyN2
+			}
+		}
+	}
+
+	// Feature 4: proportion of dark modules, in steps of 5% away from 50%.
+	dark := 0
+
+	for _, row := range m.modules {
+		for _, d := range row {
+			if d {
+				dark++
+			}
+		}
+	}
+
+	total := m.size * m.size
+	result += abs(dark*2-total) * 10 / total * penaltyN4
+
+	return result
+}
+
+// lightRun reports whether all modules from index from to to (exclusive) in
+// line i are light, where modules outside the symbol count as light.
+func (m *matrix) lightRun(get func(i, j int) bool, i, from, to int) bool {
+	for j := from; j < to; j++ {
+		if j >= 0 && j < m.size && get(i, j) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,62 @@
+package qr
+
+import "testing"
+
+func TestApplyMaskTwiceRestoresMatrix(t *testing.T) {
+	m := newMatrix(2)
+	m.drawFunctionPatterns()
+	m.drawCodewords([]byte{0xde, 0xad, 0xbe, 0xef})
+
+	before := make([][]bool, m.size)
+
+	for y := range m.modules {
+		before[y] = append([]bool{}, m.modules[y]...)
+	}
+
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.applyMask(mask)
+	}
+
+	for y := range m.modules {
+		for x := range m.modules[y] {
+			if m.modules[y][x] != before[y][x] {
+				t.Fatalf("Expected module %d,%d to be restored", x, y)
+			}
+		}
+	}
+}
+
+func TestPenaltyRules(t *testing.T) {
+	m := newMatrix(1)
+
+	// an all light symbol: 21 runs of 21 per direction, 400 2x2 blocks and
+	// 0% dark modules
+	expected := 2*21*(penaltyN1+16) + 20*20*penaltyN2 + 10*penaltyN4
+
+	if got := m.penalty(); got != expected {
+		t.Errorf("Expected penalty %d for an empty symbol, got %d", expected, got)
+	}
+
+}
+
+func TestPenaltyFinderLikePattern(t *testing.T) {
+	// Both rows have the same runs, 2x2 blocks and dark modules, only the
+	// first one contains the 1:1:3:1:1 finder-like pattern.
+	penalty := func(row []bool) int {
+		m := newMatrix(1)
+
+		for x, dark := range row {
+			m.modules[0][x+7] = dark
+		}
+
+		return m.penalty()
+	}
+
+	finder := penalty([]bool{true, false, true, true, true, false, true})
+	other := penalty([]bool{true, false, true, true, false, true, true})
+
+	if finder-other != penaltyN3 {
+		t.Errorf("Expected the finder-like pattern to add %d, got %d", penaltyN3, finder-other)
+	}
+}
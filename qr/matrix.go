@@ -0,0 +1,211 @@
+package qr
+
+// matrix is the module matrix of a symbol during its construction.
+type matrix struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+
+	m := &matrix{
+		version:  version,
+		size:     size,
+		modules:  make([][]bool, size),
+		function: make([][]bool, size),
+	}
+
+	for y := 0; y < size; y++ {
+		m.modules[y] = make([]bool, size)
+		m.function[y] = make([]bool, size)
+	}
+
+	return m
+}
+
+// setFunction sets the module at column x and row y and marks it as function module.
+func (m *matrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.function[y][x] = true
+}
+
+// drawFunctionPatterns draws finder, timing and alignment patterns and reserves
+// the format and version information areas, see [1] Chapter 6.3.
+func (m *matrix) drawFunctionPatterns() {
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinderPattern(3, 3)
+	m.drawFinderPattern(m.size-4, 3)
+	m.drawFinderPattern(3, m.size-4)
+
+	positions := alignmentPositions(m.version)
+	last := len(positions) - 1
+
+	for i, y := range positions {
+		for j, x := range positions {
+			// skip the three corners occupied by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+
+			m.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// reserve the format areas, the real bits are drawn after masking
+	m.drawFormatBits(L, 0)
+	m.drawVersionBits()
+}
+
+// drawFinderPattern draws a finder pattern with its separator around the given center.
+func (m *matrix) drawFinderPattern(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+
+			if x < 0 || x >= m.size || y < 0 || y >= m.size {
+				continue
+			}
+
+			d := max(abs(dx), abs(dy))
+			m.setFunction(x, y, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws an alignment pattern around the given center.
+func (m *matrix) drawAlignmentPattern(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatBits returns the 15 bit format information for the given level and mask,
+// a BCH(15,5) code masked with 101010000010010, see [1] Chapter 7.9.
+func formatBits(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+	rem := data
+
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawFormatBits draws both copies of the format information and the dark module.
+func (m *matrix) drawFormatBits(level Level, mask int) {
+	bits := formatBits(level, mask)
+	bit := func(i int) bool { return bits>>uint(i)&1 != 0 }
+
+	// first copy, around the top left finder pattern
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	// second copy, split between the other two finder patterns
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+
+	m.setFunction(8, m.size-8, true)
+}
+
+// versionBits returns the 18 bit version information, a BCH(18,6) code, see [1] Chapter 7.10.
+func versionBits(version int) int {
+	rem := version
+
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1f25
+	}
+
+	return version<<12 | rem
+}
+
+// drawVersionBits draws both copies of the version information for version 7 and up.
+func (m *matrix) drawVersionBits() {
+	if m.version < 7 {
+		return
+	}
+
+	bits := versionBits(m.version)
+
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 != 0
+		a, b := m.size-11+i%3, i/3
+
+		m.setFunction(a, b, dark)
+		m.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the two module wide columns from the
+// bottom right corner upwards and downwards in turn, skipping function modules,
+// see [1] Chapter 7.7.3.
+func (m *matrix) drawCodewords(data []byte) {
+	i := 0
+
+	for right := m.size - 1; right >= 1; right -= 2 {
+		// the vertical timing pattern is skipped as a whole
+		if right == 6 {
+			right = 5
+		}
+
+		upward := (right+1)&2 == 0
+
+		for vert := 0; vert < m.size; vert++ {
+			y := vert
+
+			if upward {
+				y = m.size - 1 - vert
+			}
+
+			for j := 0; j < 2; j++ {
+				x := right - j
+
+				if m.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+
+				m.modules[y][x] = data[i/8]>>uint(7-i%8)&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
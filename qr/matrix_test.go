@@ -0,0 +1,48 @@
+package qr
+
+import "testing"
+
+func TestFormatBits(t *testing.T) {
+	// [1] Annex C, Table C.1
+	expected := map[Level]int{L: 0x77c4, M: 0x5412, Q: 0x355f, H: 0x1689}
+
+	for level, bits := range expected {
+		if got := formatBits(level, 0); got != bits {
+			t.Errorf("Expected format bits %#x for %s with mask 0, got %#x", bits, level, got)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	// [1] Annex D, Table D.1
+	expected := map[int]int{7: 0x07c94, 21: 0x15683, 40: 0x28c69}
+
+	for version, bits := range expected {
+		if got := versionBits(version); got != bits {
+			t.Errorf("Expected version bits %#x for version %d, got %#x", bits, version, got)
+		}
+	}
+}
+
+func TestFunctionPatternModuleCount(t *testing.T) {
+	// All modules that are not function modules hold data, error correction
+	// and remainder bits.
+	for version := MinVersion; version <= MaxVersion; version++ {
+		m := newMatrix(version)
+		m.drawFunctionPatterns()
+
+		free := 0
+
+		for _, row := range m.function {
+			for _, f := range row {
+				if !f {
+					free++
+				}
+			}
+		}
+
+		if free != rawDataModules(version) {
+			t.Errorf("Expected %d data modules for version %d, got %d", rawDataModules(version), version, free)
+		}
+	}
+}
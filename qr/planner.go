@@ -0,0 +1,146 @@
+package qr
+
+import (
+	"encoding/base64"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+// Plan describes which QR code versions a payload needs as base 45 in
+// alphanumeric mode, compared to base 64 in byte mode.
+type Plan struct {
+	// Bytes is the length of the raw payload.
+	Bytes int
+
+	// Base45Length is the length of the base 45 encoded payload.
+	Base45Length int
+
+	// Base64Length is the length of the padded base 64 encoded payload.
+	Base64Length int
+
+	// Levels holds the plan for each error correction level, indexed by Level.
+	Levels [4]LevelPlan
+}
+
+// LevelPlan describes the smallest version for one error correction level.
+// Versions are 0 if the payload does not fit into version 40.
+type LevelPlan struct {
+	// Level is the error correction level.
+	Level Level
+
+	// Version is the smallest version that fits the base 45 payload.
+	Version int
+
+	// Headroom is the number of base 45 characters left in Version.
+	Headroom int
+
+	// HeadroomBytes is the number of raw bytes that could be added to the
+	// payload without needing a larger version.
+	HeadroomBytes int
+
+	// Base64Version is the smallest version that fits the base 64 payload in byte mode.
+	Base64Version int
+
+	// Base64Headroom is the number of base 64 characters left in Base64Version.
+	Base64Headroom int
+}
+
+// PlanBytes returns the capacity plan for the given raw payload.
+func PlanBytes(data []byte) Plan {
+	p := Plan{
+		Bytes:        len(data),
+		Base45Length: encodedLength(len(data)),
+		Base64Length: base64.StdEncoding.EncodedLen(len(data)),
+	}
+
+	for level := L; level <= H; level++ {
+		lp := LevelPlan{Level: level}
+
+		for version := MinVersion; version <= MaxVersion; version++ {
+			capacity := alphanumericCapacity(version, level)
+
+			if lp.Version == 0 && p.Base45Length <= capacity {
+				lp.Version = version
+				lp.Headroom = capacity - p.Base45Length
+				lp.HeadroomBytes = decodedLength(capacity) - p.Bytes
+			}
+
+			capacity = byteCapacity(version, level)
+
+			if lp.Base64Version == 0 && p.Base64Length <= capacity {
+				lp.Base64Version = version
+				lp.Base64Headroom = capacity - p.Base64Length
+			}
+		}
+
+		p.Levels[level] = lp
+	}
+
+	return p
+}
+
+// PlanText returns the capacity plan for the given base 45 encoded payload.
+// The text is decoded first, so invalid input is rejected with the errors of base45.Decode.
+func PlanText(text []byte) (Plan, error) {
+	data, err := base45.Decode(text)
+
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return PlanBytes(data), nil
+}
+
+// AlphanumericCapacity returns the maximum number of characters of a single
+// alphanumeric segment in a symbol of the given version and level.
+func AlphanumericCapacity(version int, level Level) (int, error) {
+	if err := checkVersionLevel(version, level); err != nil {
+		return 0, err
+	}
+
+	return alphanumericCapacity(version, level), nil
+}
+
+// ByteCapacity returns the maximum number of bytes of a single byte mode
+// segment in a symbol of the given version and level.
+func ByteCapacity(version int, level Level) (int, error) {
+	if err := checkVersionLevel(version, level); err != nil {
+		return 0, err
+	}
+
+	return byteCapacity(version, level), nil
+}
+
+func alphanumericCapacity(version int, level Level) int {
+	available := dataCodewords(version, level)*8 - 4 - countBits(version)
+	chars := available / 11 * 2
+
+	if available%11 >= 6 {
+		chars++
+	}
+
+	return chars
+}
+
+func byteCapacity(version int, level Level) int {
+	// byte mode character count indicator, see [1] Table 3
+	count := 8
+
+	if version >= 10 {
+		count = 16
+	}
+
+	return (dataCodewords(version, level)*8 - 4 - count) / 8
+}
+
+// decodedLength returns the maximum number of bytes whose base 45 encoding
+// fits into the given number of characters.
+func decodedLength(chars int) int {
+	n := chars / 3 * 2
+
+	if chars%3 == 2 {
+		n++
+	}
+
+	return n
+}
@@ -0,0 +1,111 @@
+package qr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestByteCapacitiesMatchStandard(t *testing.T) {
+	// [1] Table 7, data capacity in bytes
+	expected := map[int][4]int{
+		1:  {17, 14, 11, 7},
+		10: {271, 213, 151, 119},
+		40: {2953, 2331, 1663, 1273},
+	}
+
+	for version, capacities := range expected {
+		for level, capacity := range capacities {
+			if got, _ := ByteCapacity(version, Level(level)); got != capacity {
+				t.Errorf("Expected byte capacity %d for %d-%s, got %d", capacity, version, Level(level), got)
+			}
+		}
+	}
+}
+
+func TestPlanBytes(t *testing.T) {
+	// 16 bytes are 24 base 45 and 24 base 64 characters
+	p := PlanBytes(make([]byte, 16))
+
+	if p.Base45Length != 24 || p.Base64Length != 24 {
+		t.Fatalf("Expected 24 characters for both encodings, got %d and %d", p.Base45Length, p.Base64Length)
+	}
+
+	expected := [4]LevelPlan{
+		{Level: L, Version: 1, Headroom: 1, HeadroomBytes: 0, Base64Version: 2, Base64Headroom: 8},
+		{Level: M, Version: 2, Headroom: 14, HeadroomBytes: 9, Base64Version: 2, Base64Headroom: 2},
+		{Level: Q, Version: 2, Headroom: 5, HeadroomBytes: 3, Base64Version: 3, Base64Headroom: 8},
+		{Level: H, Version: 3, Headroom: 11, HeadroomBytes: 7, Base64Version: 3, Base64Headroom: 0},
+	}
+
+	if p.Levels != expected {
+		t.Errorf("Expected plan %+v, got %+v", expected, p.Levels)
+	}
+}
+
+func TestPlanMatchesEncoder(t *testing.T) {
+	for _, n := range []int{1, 100, 500, 1500, 2800} {
+		data := bytes.Repeat([]byte{0xab}, n)
+		p := PlanBytes(data)
+
+		for level := L; level <= H; level++ {
+			s, err := EncodeBytes(data, level)
+
+			if err != nil {
+				if p.Levels[level].Version != 0 {
+					t.Errorf("Expected plan for %d bytes at %s to report no fit, got version %d", n, level, p.Levels[level].Version)
+				}
+
+				continue
+			}
+
+			if s.Version != p.Levels[level].Version {
+				t.Errorf("Expected plan for %d bytes at %s to match version %d, got %d", n, level, s.Version, p.Levels[level].Version)
+			}
+
+			// the headroom must be usable without a larger version
+			grown, _ := EncodeBytes(make([]byte, n+p.Levels[level].HeadroomBytes), level)
+
+			if grown.Version != s.Version {
+				t.Errorf("Expected headroom of %d bytes at %s to fit version %d", p.Levels[level].HeadroomBytes, level, s.Version)
+			}
+		}
+	}
+}
+
+func TestPlanText(t *testing.T) {
+	p, err := PlanText([]byte("%69 VD92EX0"))
+
+	if err != nil {
+		t.Fatalf("Expected plan, got error \"%s\"", err)
+	}
+
+	if p.Bytes != 7 || p.Base45Length != 11 {
+		t.Errorf("Expected 7 bytes and 11 characters, got %d and %d", p.Bytes, p.Base45Length)
+	}
+
+	if _, err := PlanText([]byte("GGW")); err != base45.ErrInvalidEncodedDataOverflow {
+		t.Errorf("Expected ErrInvalidEncodedDataOverflow, got \"%v\"", err)
+	}
+}
+
+func TestCapacityInvalidArguments(t *testing.T) {
+	for _, version := range []int{0, 41} {
+		if _, err := AlphanumericCapacity(version, L); err != ErrInvalidVersion {
+			t.Errorf("Expected ErrInvalidVersion for version %d, got \"%v\"", version, err)
+		}
+
+		if _, err := ByteCapacity(version, L); err != ErrInvalidVersion {
+			t.Errorf("Expected ErrInvalidVersion for version %d, got \"%v\"", version, err)
+		}
+	}
+
+	if _, err := AlphanumericCapacity(1, Level(7)); err != ErrInvalidLevel {
+		t.Errorf("Expected ErrInvalidLevel, got \"%v\"", err)
+	}
+
+	if _, err := ByteCapacity(1, Level(-1)); err != ErrInvalidLevel {
+		t.Errorf("Expected ErrInvalidLevel, got \"%v\"", err)
+	}
+}
@@ -0,0 +1,294 @@
+// Package qr generates QR code symbols for base 45 encoded data by
+// https://www.iso.org/standard/62021.html
+//
+// Base 45 exists to fit QR codes in alphanumeric mode (RFC 9285 chapter 4),
+// so this package always encodes its input as a single alphanumeric segment,
+// where a generic QR library might fall back to the less efficient byte mode.
+package qr
+
+import (
+	"strings"
+
+	"github.com/adrianrudnik/base45-go/reedsolomon"
+)
+
+/*
+	Chapter references:
+
+	[1] ISO/IEC 18004:2015
+        Information technology - Automatic identification and data capture
+        techniques - QR Code bar code symbology specification
+*/
+
+// Level is the error correction level of a symbol.
+type Level int
+
+// Error correction levels, recovering roughly 7%, 15%, 25% and 30% of the codewords.
+const (
+	L Level = iota
+	M
+	Q
+	H
+)
+
+// formatBits returns the two bit error correction level indicator, see [1] Table 12.
+func (l Level) formatBits() int {
+	return [...]int{L: 1, M: 0, Q: 3, H: 2}[l]
+}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case L:
+		return "L"
+	case M:
+		return "M"
+	case Q:
+		return "Q"
+	case H:
+		return "H"
+	}
+
+	return "invalid"
+}
+
+const (
+	// MinVersion is the smallest QR code version.
+	MinVersion = 1
+
+	// MaxVersion is the largest QR code version.
+	MaxVersion = 40
+)
+
+// Symbol is a generated QR code.
+type Symbol struct {
+	// Version is the version of the symbol, between 1 and 40.
+	Version int
+
+	// Level is the error correction level of the symbol.
+	Level Level
+
+	// Mask is the applied data mask pattern, between 0 and 7.
+	Mask int
+
+	// Size is the number of modules per side, 17 + 4 * Version.
+	Size int
+
+	// Modules holds the module matrix indexed by row and column, true is dark.
+	// The quiet zone around the symbol is not included.
+	Modules [][]bool
+}
+
+// String renders the symbol as text, one line per row, with "#" for dark and
+// "." for light modules.
+func (s *Symbol) String() string {
+	var b strings.Builder
+
+	for _, row := range s.Modules {
+		for _, dark := range row {
+			if dark {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// Encode generates a symbol of the smallest version that fits the given base 45
+// text at the given error correction level. The text is encoded in alphanumeric
+// mode, characters outside the base 45 alphabet are rejected with
+// base45.ErrInvalidEncodingCharacters.
+func Encode(text []byte, level Level) (*Symbol, error) {
+	version, err := smallestVersion(len(text), level)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return EncodeVersion(text, level, version)
+}
+
+// EncodeVersion works like Encode with a fixed version.
+func EncodeVersion(text []byte, level Level, version int) (*Symbol, error) {
+	capacity, err := checkCapacity(len(text), level, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var bits bitBuffer
+
+	if err := bits.writeAlphanumeric(text, version); err != nil {
+		return nil, err
+	}
+
+	return newSymbol(bits.codewords(capacity), version, level, autoMask), nil
+}
+
+// EncodeBytes generates a symbol of the smallest version that fits the base 45
+// encoding of the given bytes. The alphanumeric segment is written directly
+// from the bytes, without building the intermediate base 45 string, and equals
+// the one of Encode(base45.Encode(data), level).
+func EncodeBytes(data []byte, level Level) (*Symbol, error) {
+	chars := encodedLength(len(data))
+	version, err := smallestVersion(chars, level)
+
+	if err != nil {
+		return nil, err
+	}
+
+	capacity, err := checkCapacity(chars, level, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var bits bitBuffer
+	bits.writeAlphanumericBytes(data, version)
+
+	return newSymbol(bits.codewords(capacity), version, level, autoMask), nil
+}
+
+// smallestVersion returns the smallest version an alphanumeric segment with the
+// given number of characters fits in at the given level.
+func smallestVersion(chars int, level Level) (int, error) {
+	if level < L || level > H {
+		return 0, ErrInvalidLevel
+	}
+
+	for version := MinVersion; version <= MaxVersion; version++ {
+		if segmentBits(chars, version) <= dataCodewords(version, level)*8 {
+			return version, nil
+		}
+	}
+
+	return 0, ErrDataTooLong
+}
+
+// checkCapacity checks an alphanumeric segment with the given number of
+// characters fits the version and level, and returns its data codewords.
+func checkCapacity(chars int, level Level, version int) (int, error) {
+	if err := checkVersionLevel(version, level); err != nil {
+		return 0, err
+	}
+
+	capacity := dataCodewords(version, level)
+
+	if segmentBits(chars, version) > capacity*8 || chars >= 1<<uint(countBits(version)) {
+		return 0, ErrDataTooLong
+	}
+
+	return capacity, nil
+}
+
+// checkVersionLevel checks the version and level are within their ranges.
+func checkVersionLevel(version int, level Level) error {
+	if level < L || level > H {
+		return ErrInvalidLevel
+	}
+
+	if version < MinVersion || version > MaxVersion {
+		return ErrInvalidVersion
+	}
+
+	return nil
+}
+
+// autoMask makes newSymbol pick the mask pattern with the lowest penalty.
+const autoMask = -1
+
+// newSymbol adds the error correction codewords to the data codewords and
+// draws the symbol with the given mask pattern, or with the one of the lowest
+// penalty for autoMask.
+func newSymbol(data []byte, version int, level Level, mask int) *Symbol {
+	m := newMatrix(version)
+	m.drawFunctionPatterns()
+	m.drawCodewords(interleave(data, version, level))
+
+	if mask == autoMask {
+		mask = m.bestMask(level)
+	}
+
+	m.applyMask(mask)
+	m.drawFormatBits(level, mask)
+
+	return &Symbol{
+		Version: version,
+		Level:   level,
+		Mask:    mask,
+		Size:    m.size,
+		Modules: m.modules,
+	}
+}
+
+// bestMask returns the mask pattern with the lowest penalty, leaving the data
+// modules unmasked.
+func (m *matrix) bestMask(level Level) int {
+	best, bestPenalty := 0, -1
+
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormatBits(level, mask)
+
+		if p := m.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+
+		// masks are XOR based, applying one again reverts it
+		m.applyMask(mask)
+	}
+
+	return best
+}
+
+// interleave splits the data codewords into blocks, adds the error correction
+// codewords to each block and interleaves them, see [1] Chapter 7.6.
+func interleave(data []byte, version int, level Level) []byte {
+	numBlocks := eccBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := rawDataModules(version) / 8
+
+	// The first blocks are one data codeword shorter than the last ones.
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	dataBlocks := make([][]byte, numBlocks)
+	eccData := make([][]byte, numBlocks)
+
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortBlockLen - eccLen
+
+		if i >= numShortBlocks {
+			n++
+		}
+
+		dataBlocks[i] = data[k : k+n]
+		k += n
+
+		// The block sizes are bound by the tables, so this never fails.
+		eccData[i], _ = reedsolomon.Parity(dataBlocks[i], eccLen)
+	}
+
+	out := make([]byte, 0, rawCodewords)
+
+	for i := 0; i <= shortBlockLen-eccLen; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+
+	for i := 0; i < eccLen; i++ {
+		for _, block := range eccData {
+			out = append(out, block[i])
+		}
+	}
+
+	return out
+}
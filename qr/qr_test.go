@@ -0,0 +1,216 @@
+package qr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+// readCodewords reads the format information and the codewords back from the
+// symbol, independent of the mask chosen by the encoder.
+func readCodewords(t *testing.T, s *Symbol) []byte {
+	t.Helper()
+
+	// first copy of the format information, see drawFormatBits
+	format := 0
+	bit := func(x, y, i int) {
+		if s.Modules[y][x] {
+			format |= 1 << uint(i)
+		}
+	}
+
+	for i := 0; i <= 5; i++ {
+		bit(8, i, i)
+	}
+
+	bit(8, 7, 6)
+	bit(8, 8, 7)
+	bit(7, 8, 8)
+
+	for i := 9; i < 15; i++ {
+		bit(14-i, 8, i)
+	}
+
+	if format != formatBits(s.Level, s.Mask) {
+		t.Fatalf("Expected format bits %#x, got %#x", formatBits(s.Level, s.Mask), format)
+	}
+
+	m := newMatrix(s.Version)
+	m.drawFunctionPatterns()
+
+	var out []byte
+	n := 0
+
+	for right := s.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := 0; vert < s.Size; vert++ {
+			y := vert
+
+			if (right+1)&2 == 0 {
+				y = s.Size - 1 - vert
+			}
+
+			for j := 0; j < 2; j++ {
+				x := right - j
+
+				if m.function[y][x] {
+					continue
+				}
+
+				if n%8 == 0 {
+					out = append(out, 0)
+				}
+
+				if s.Modules[y][x] != masked(s.Mask, x, y) {
+					out[n/8] |= 0x80 >> uint(n%8)
+				}
+
+				n++
+			}
+		}
+	}
+
+	return out[:rawDataModules(s.Version)/8]
+}
+
+func TestEncodeHelloWorld(t *testing.T) {
+	// "HELLO WORLD" as 1-Q, see https://www.thonky.com/qr-code-tutorial/
+	expected := []byte{
+		32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236,
+		168, 72, 22, 82, 217, 54, 156, 0, 46, 15, 180, 122, 16,
+	}
+
+	s, err := Encode([]byte("HELLO WORLD"), Q)
+
+	if err != nil {
+		t.Fatalf("Expected symbol, got error \"%s\"", err)
+	}
+
+	if s.Version != 1 || s.Size != 21 {
+		t.Errorf("Expected version 1 with 21 modules, got version %d with %d modules", s.Version, s.Size)
+	}
+
+	if got := readCodewords(t, s); !bytes.Equal(got, expected) {
+		t.Errorf("Expected codewords %v, got %v", expected, got)
+	}
+}
+
+func TestEncodeFinderPatterns(t *testing.T) {
+	s, _ := Encode(base45.Encode([]byte("Hello!!")), M)
+
+	// top left finder pattern with separator, row by row
+	expected := []string{
+		"#######.",
+		"#.....#.",
+		"#.###.#.",
+		"#.###.#.",
+		"#.###.#.",
+		"#.....#.",
+		"#######.",
+		"........",
+	}
+
+	lines := strings.Split(s.String(), "\n")
+
+	for y, row := range expected {
+		if got := lines[y][:8]; got != row {
+			t.Errorf("Expected row %d to start with %s, got %s", y, row, got)
+		}
+	}
+}
+
+func TestEncodeAllVersionsAndLevels(t *testing.T) {
+	for version := MinVersion; version <= MaxVersion; version += 3 {
+		for level := L; level <= H; level++ {
+			// a payload that just needs the given version
+			data := make([]byte, alphanumericCapacity(version, level)/3*2)
+
+			for i := range data {
+				data[i] = byte(i * 7)
+			}
+
+			text := base45.Encode(data)
+			s, err := EncodeVersion(text, level, version)
+
+			if err != nil {
+				t.Fatalf("Expected symbol for %d-%s, got error \"%s\"", version, level, err)
+			}
+
+			var bits bitBuffer
+			bits.writeAlphanumeric(text, version)
+			expected := interleave(bits.codewords(dataCodewords(version, level)), version, level)
+
+			if got := readCodewords(t, s); !bytes.Equal(got, expected) {
+				t.Errorf("Unexpected codewords for %d-%s", version, level)
+			}
+		}
+	}
+}
+
+func TestEncodePicksSmallestVersion(t *testing.T) {
+	for _, entry := range []struct {
+		chars   int
+		level   Level
+		version int
+	}{
+		{25, L, 1},
+		{26, L, 2},
+		{10, H, 1},
+		{11, H, 2},
+		{4296, L, 40},
+	} {
+		s, err := Encode(bytes.Repeat([]byte("A"), entry.chars), entry.level)
+
+		if err != nil {
+			t.Fatalf("Expected symbol, got error \"%s\"", err)
+		}
+
+		if s.Version != entry.version {
+			t.Errorf("Expected version %d for %d characters at %s, got %d", entry.version, entry.chars, entry.level, s.Version)
+		}
+	}
+}
+
+func TestEncodeDataTooLong(t *testing.T) {
+	if _, err := Encode(bytes.Repeat([]byte("A"), 4297), L); err != ErrDataTooLong {
+		t.Errorf("Expected ErrDataTooLong, got \"%v\"", err)
+	}
+
+	if _, err := EncodeVersion(bytes.Repeat([]byte("A"), 26), L, 1); err != ErrDataTooLong {
+		t.Errorf("Expected ErrDataTooLong for a fixed version, got \"%v\"", err)
+	}
+}
+
+func TestEncodeInvalidInput(t *testing.T) {
+	if _, err := Encode([]byte("hello"), M); err != base45.ErrInvalidEncodingCharacters {
+		t.Errorf("Expected ErrInvalidEncodingCharacters, got \"%v\"", err)
+	}
+
+	if _, err := Encode([]byte("HELLO"), Level(4)); err != ErrInvalidLevel {
+		t.Errorf("Expected ErrInvalidLevel, got \"%v\"", err)
+	}
+
+	if _, err := EncodeVersion([]byte("HELLO"), M, 41); err != ErrInvalidVersion {
+		t.Errorf("Expected ErrInvalidVersion, got \"%v\"", err)
+	}
+}
+
+func TestEncodeBytesMatchesEncode(t *testing.T) {
+	data := []byte("Hello, this is a QR code built straight from bytes!")
+
+	expected, _ := Encode(base45.Encode(data), Q)
+	got, err := EncodeBytes(data, Q)
+
+	if err != nil {
+		t.Fatalf("Expected symbol, got error \"%s\"", err)
+	}
+
+	if got.String() != expected.String() || got.Version != expected.Version || got.Mask != expected.Mask {
+		t.Errorf("Expected identical symbols from bytes and from the encoded text")
+	}
+}
@@ -0,0 +1,60 @@
+package qr
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Reference symbols rendered by github.com/skip2/go-qrcode, an independent
+// encoder, with "#" for dark and "." for light modules. The mask pattern is the
+// one that encoder picked, its penalty scoring differs slightly from this package.
+var referenceSymbols = []struct {
+	file    string
+	text    string
+	version int
+	level   Level
+	mask    int
+}{
+	{"hello-world-1-Q.txt", "HELLO WORLD", 1, Q, 0},
+	{"quick-brown-fox-7-M.txt", "THE QUICK BROWN FOX JUMPS OVER THE LAZY DOG $%*+-./: BASE45 IN ALPHANUMERIC MODE", 7, M, 0},
+	{"base-forty-five-20-H.txt", string(bytes.Repeat([]byte("BASE FORTY FIVE "), 30)), 20, H, 4},
+}
+
+func TestReferenceSymbols(t *testing.T) {
+	for _, entry := range referenceSymbols {
+		expected, err := os.ReadFile(filepath.Join("testdata", entry.file))
+
+		if err != nil {
+			t.Fatalf("Expected reference symbol, got error \"%s\"", err)
+		}
+
+		var bits bitBuffer
+
+		if err := bits.writeAlphanumeric([]byte(entry.text), entry.version); err != nil {
+			t.Fatalf("Expected segment, got error \"%s\"", err)
+		}
+
+		s := newSymbol(bits.codewords(dataCodewords(entry.version, entry.level)), entry.version, entry.level, entry.mask)
+
+		if got := s.String(); got != string(expected) {
+			t.Errorf("Symbol differs from reference %s:\n%s", entry.file, got)
+		}
+	}
+}
+
+func TestEncodeMatchesReferenceHelloWorld(t *testing.T) {
+	// Both encoders agree on the mask pattern for this symbol.
+	expected, _ := os.ReadFile(filepath.Join("testdata", "hello-world-1-Q.txt"))
+
+	s, err := Encode([]byte("HELLO WORLD"), Q)
+
+	if err != nil {
+		t.Fatalf("Expected symbol, got error \"%s\"", err)
+	}
+
+	if got := s.String(); got != string(expected) {
+		t.Errorf("Symbol differs from reference:\n%s", got)
+	}
+}
@@ -0,0 +1,208 @@
+package qr
+
+import (
+	"bytes"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+/*
+	[1] Chapter 7.4.4:
+
+	Alphanumeric mode encodes data from a set of 45 characters. The base 45
+	alphabet of RFC 9285 is this set in the same order, so the index of a
+	character in base45.Alphabet is its alphanumeric mode value.
+
+	Input data characters are divided into groups of two characters which are
+	encoded as 11-bit binary codes. The character value of the first character
+	is multiplied by 45 and the character value of the second digit is added
+	to the product. If the number of input data characters is not a multiple
+	of two, the character value of the final character is encoded as a 6-bit
+	binary number.
+*/
+
+// modeAlphanumeric is the 4 bit mode indicator of the alphanumeric mode.
+const modeAlphanumeric = 0x2
+
+// countBits returns the length of the character count indicator of the
+// alphanumeric mode for the given version, see [1] Table 3.
+func countBits(version int) int {
+	switch {
+	case version <= 9:
+		return 9
+	case version <= 26:
+		return 11
+	default:
+		return 13
+	}
+}
+
+// segmentBits returns the length in bits of an alphanumeric segment with the
+// given number of characters, including mode and count indicators.
+func segmentBits(chars, version int) int {
+	return 4 + countBits(version) + chars/2*11 + chars%2*6
+}
+
+// bitBuffer is an append-only sequence of bits, most significant bit first.
+type bitBuffer struct {
+	data []byte
+	n    int
+}
+
+// write appends the lowest length bits of value.
+func (b *bitBuffer) write(value uint, length int) {
+	for i := length - 1; i >= 0; i-- {
+		if b.n%8 == 0 {
+			b.data = append(b.data, 0)
+		}
+
+		if value>>uint(i)&1 != 0 {
+			b.data[b.n/8] |= 0x80 >> uint(b.n%8)
+		}
+
+		b.n++
+	}
+}
+
+// writeAlphanumeric appends an alphanumeric segment of the given base 45 text.
+func (b *bitBuffer) writeAlphanumeric(text []byte, version int) error {
+	b.write(modeAlphanumeric, 4)
+	b.write(uint(len(text)), countBits(version))
+
+	for i := 0; i < len(text); i += 2 {
+		first := bytes.IndexByte(base45.Alphabet, text[i])
+
+		if first < 0 {
+			return base45.ErrInvalidEncodingCharacters
+		}
+
+		if i+1 == len(text) {
+			b.write(uint(first), 6)
+			break
+		}
+
+		second := bytes.IndexByte(base45.Alphabet, text[i+1])
+
+		if second < 0 {
+			return base45.ErrInvalidEncodingCharacters
+		}
+
+		b.write(uint(first*45+second), 11)
+	}
+
+	return nil
+}
+
+// codewords terminates and pads the bit stream to the given number of data codewords.
+func (b *bitBuffer) codewords(capacity int) []byte {
+	/*
+		[1] Chapter 7.4.9 and 7.4.10:
+
+		The end of data is indicated by a terminator of up to four zero bits,
+		the bit stream is then filled up to a full codeword with zero bits and
+		the remaining codewords are filled with alternating 11101100 and
+		00010001 pad codewords.
+	*/
+	terminator := capacity*8 - b.n
+
+	if terminator > 4 {
+		terminator = 4
+	}
+
+	b.write(0, terminator)
+	b.write(0, (8-b.n%8)%8)
+
+	for pad := uint(0xec); len(b.data) < capacity; pad ^= 0xec ^ 0x11 {
+		b.write(pad, 8)
+	}
+
+	return b.data
+}
+
+// encodedLength returns the base 45 length of n bytes, three characters per
+// two bytes and two for a trailing byte.
+func encodedLength(n int) int {
+	return n/2*3 + n%2*2
+}
+
+// writeAlphanumericBytes appends the alphanumeric segment of the base 45
+// encoding of the given bytes. The character values are calculated as in
+// base45.Encode, but written as 11 bit pairs right away instead of being
+// looked up in the alphabet and back.
+func (b *bitBuffer) writeAlphanumericBytes(data []byte, version int) {
+	b.write(modeAlphanumeric, 4)
+	b.write(uint(encodedLength(len(data))), countBits(version))
+
+	// Triplets have an odd length, so a pair can span two triplets.
+	pending := -1
+
+	emit := func(value int) {
+		if pending < 0 {
+			pending = value
+			return
+		}
+
+		b.write(uint(pending*45+value), 11)
+		pending = -1
+	}
+
+	for i := 0; i+1 < len(data); i += 2 {
+		n := int(data[i])<<8 | int(data[i+1])
+
+		emit(n % 45)
+		emit(n / 45 % 45)
+		emit(n / (45 * 45))
+	}
+
+	if len(data)%2 == 1 {
+		a := int(data[len(data)-1])
+
+		emit(a % 45)
+		emit(a / 45)
+	}
+
+	if pending >= 0 {
+		b.write(uint(pending), 6)
+	}
+}
+
+// Segment is the bit stream of a QR code segment, including its mode and
+// character count indicators.
+type Segment struct {
+	bits bitBuffer
+}
+
+// AlphanumericSegment returns the alphanumeric segment of the base 45 encoding
+// of the given bytes for a symbol of the given version, which determines the
+// length of the character count indicator. The result is identical to
+// segmenting the output of base45.Encode, without building that string.
+func AlphanumericSegment(data []byte, version int) (*Segment, error) {
+	if version < MinVersion || version > MaxVersion {
+		return nil, ErrInvalidVersion
+	}
+
+	if encodedLength(len(data)) >= 1<<uint(countBits(version)) {
+		return nil, ErrDataTooLong
+	}
+
+	s := &Segment{}
+	s.bits.writeAlphanumericBytes(data, version)
+
+	return s, nil
+}
+
+// Len returns the length of the segment in bits.
+func (s *Segment) Len() int {
+	return s.bits.n
+}
+
+// Bit returns the bit at the given index, where true is a 1 bit.
+func (s *Segment) Bit(i int) bool {
+	return s.bits.data[i/8]&(0x80>>uint(i%8)) != 0
+}
+
+// Bytes returns the bits packed most significant bit first. The last byte is
+// padded with zero bits if the length is not a multiple of eight.
+func (s *Segment) Bytes() []byte {
+	return append([]byte{}, s.bits.data...)
+}
@@ -0,0 +1,118 @@
+package qr
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestAlphanumericCodewords(t *testing.T) {
+	// "HELLO WORLD" as 1-Q, see https://www.thonky.com/qr-code-tutorial/
+	expected := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236}
+
+	var bits bitBuffer
+
+	if err := bits.writeAlphanumeric([]byte("HELLO WORLD"), 1); err != nil {
+		t.Fatalf("Expected segment, got error \"%s\"", err)
+	}
+
+	if got := bits.codewords(dataCodewords(1, Q)); !bytes.Equal(got, expected) {
+		t.Errorf("Expected codewords %v, got %v", expected, got)
+	}
+}
+
+func TestAlphanumericInvalidCharacters(t *testing.T) {
+	var bits bitBuffer
+
+	if err := bits.writeAlphanumeric([]byte("hello"), 1); err != base45.ErrInvalidEncodingCharacters {
+		t.Errorf("Expected ErrInvalidEncodingCharacters, got \"%v\"", err)
+	}
+}
+
+func TestCountBits(t *testing.T) {
+	for version, expected := range map[int]int{1: 9, 9: 9, 10: 11, 26: 11, 27: 13, 40: 13} {
+		if got := countBits(version); got != expected {
+			t.Errorf("Expected %d count bits for version %d, got %d", expected, version, got)
+		}
+	}
+}
+
+func TestAlphanumericSegmentMatchesEncodedText(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 200; n++ {
+		data := make([]byte, n)
+		rng.Read(data)
+
+		for _, version := range []int{1, 10, 27} {
+			if encodedLength(n) >= 1<<uint(countBits(version)) {
+				continue
+			}
+
+			var expected bitBuffer
+
+			if err := expected.writeAlphanumeric(base45.Encode(data), version); err != nil {
+				t.Fatalf("Expected segment, got error \"%s\"", err)
+			}
+
+			got, err := AlphanumericSegment(data, version)
+
+			if err != nil {
+				t.Fatalf("Expected segment, got error \"%s\"", err)
+			}
+
+			if got.Len() != expected.n || !bytes.Equal(got.Bytes(), expected.data) {
+				t.Fatalf("Segment of %d bytes for version %d differs from the encoded text", n, version)
+			}
+		}
+	}
+}
+
+func TestAlphanumericSegmentAllPairs(t *testing.T) {
+	// every two byte value, which covers every character triplet
+	data := make([]byte, 0, 2*65536)
+
+	for v := 0; v < 65536; v++ {
+		data = append(data, byte(v>>8), byte(v))
+	}
+
+	for _, in := range [][]byte{data[:4000], data[4000:8000], data[len(data)-3999:]} {
+		var expected bitBuffer
+		expected.writeAlphanumeric(base45.Encode(in), 40)
+
+		got, _ := AlphanumericSegment(in, 40)
+
+		if !bytes.Equal(got.Bytes(), expected.data) {
+			t.Errorf("Segment differs from the encoded text")
+		}
+	}
+}
+
+func TestAlphanumericSegmentBits(t *testing.T) {
+	// "AB" encodes to "BB8", see RFC 9285 chapter 4.3
+	got, _ := AlphanumericSegment([]byte("AB"), 1)
+
+	expected := "0010" + "000000011" + "00111111010" + "001000"
+
+	if got.Len() != len(expected) {
+		t.Fatalf("Expected %d bits, got %d", len(expected), got.Len())
+	}
+
+	for i, c := range expected {
+		if got.Bit(i) != (c == '1') {
+			t.Errorf("Unexpected bit %d", i)
+		}
+	}
+}
+
+func TestAlphanumericSegmentInvalid(t *testing.T) {
+	if _, err := AlphanumericSegment([]byte("AB"), 0); err != ErrInvalidVersion {
+		t.Errorf("Expected ErrInvalidVersion, got \"%v\"", err)
+	}
+
+	if _, err := AlphanumericSegment(make([]byte, 400), 1); err != ErrDataTooLong {
+		t.Errorf("Expected ErrDataTooLong, got \"%v\"", err)
+	}
+}
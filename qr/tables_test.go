@@ -0,0 +1,71 @@
+package qr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCapacitiesMatchStandard(t *testing.T) {
+	// [1] Table 7, data capacity in alphanumeric characters
+	expected := map[int][4]int{
+		1:  {25, 20, 16, 10},
+		2:  {47, 38, 29, 20},
+		10: {395, 311, 221, 174},
+		40: {4296, 3391, 2420, 1852},
+	}
+
+	for version, capacities := range expected {
+		for level, capacity := range capacities {
+			if got, _ := AlphanumericCapacity(version, Level(level)); got != capacity {
+				t.Errorf("Expected capacity %d for %d-%s, got %d", capacity, version, Level(level), got)
+			}
+		}
+	}
+}
+
+func TestDataCodewordsMatchStandard(t *testing.T) {
+	expected := map[int][4]int{
+		1:  {19, 16, 13, 9},
+		40: {2956, 2334, 1666, 1276},
+	}
+
+	for version, codewords := range expected {
+		for level, n := range codewords {
+			if got := dataCodewords(version, Level(level)); got != n {
+				t.Errorf("Expected %d data codewords for %d-%s, got %d", n, version, Level(level), got)
+			}
+		}
+	}
+}
+
+func TestTotalCodewordsAreConsistent(t *testing.T) {
+	for version := MinVersion; version <= MaxVersion; version++ {
+		total := rawDataModules(version) / 8
+
+		for level := L; level <= H; level++ {
+			// the blocks must hold all codewords with at most one codeword difference
+			blocks := eccBlocks[level][version]
+			dataPerBlock := dataCodewords(version, level) / blocks
+
+			if dataPerBlock < 1 || total/blocks-eccCodewordsPerBlock[level][version] != dataPerBlock {
+				t.Errorf("Inconsistent block structure for %d-%s", version, level)
+			}
+		}
+	}
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	expected := map[int][]int{
+		1:  nil,
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		32: {6, 34, 60, 86, 112, 138},
+		40: {6, 30, 58, 86, 114, 142, 170},
+	}
+
+	for version, positions := range expected {
+		if got := alignmentPositions(version); !reflect.DeepEqual(got, positions) {
+			t.Errorf("Expected alignment positions %v for version %d, got %v", positions, version, got)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package reedsolomon
+
+import "github.com/adrianrudnik/base45-go/internal/gf256"
+
+/*
+	The decoder follows the syndrome based errors-and-erasures decoder of
+	https://en.wikiversity.org/wiki/Reed%E2%80%93Solomon_codes_for_coders
+	with the first consecutive root 2^0: Forney syndromes remove the known
+	erasures, Berlekamp-Massey finds the remaining error locator, the Chien
+	search its roots and the Forney algorithm the error magnitudes.
+*/
+
+// MaxCodewordLength is the maximum length of data and parity in bytes.
+const MaxCodewordLength = 255
+
+// generator returns the generator polynomial for the given number of parity bytes.
+func generator(parity int) []byte {
+	g := []byte{1}
+
+	for i := 0; i < parity; i++ {
+		g = polyMul(g, []byte{1, gf256.Exp(i)})
+	}
+
+	return g
+}
+
+// Parity returns the given number of Reed-Solomon parity bytes for the data.
+func Parity(data []byte, parity int) ([]byte, error) {
+	if parity < 1 || parity >= MaxCodewordLength {
+		return nil, ErrInvalidParity
+	}
+
+	if len(data)+parity > MaxCodewordLength {
+		return nil, ErrCodewordTooLong
+	}
+
+	shifted := make([]byte, len(data)+parity)
+	copy(shifted, data)
+
+	return polyRemainder(shifted, generator(parity)), nil
+}
+
+// Correct corrects the codeword of data followed by the given number of parity
+// bytes in place. The erasures are the indexes of bytes known to be wrong,
+// like unreadable characters. Up to parity erasures, or half as many unknown
+// errors, can be corrected, where each error counts as two erasures.
+// It returns the number of corrected bytes. On failure the codeword is left
+// unchanged.
+func Correct(codeword []byte, parity int, erasures []int) (int, error) {
+	if parity < 1 || parity >= len(codeword) {
+		return 0, ErrInvalidParity
+	}
+
+	if len(codeword) > MaxCodewordLength {
+		return 0, ErrCodewordTooLong
+	}
+
+	if len(erasures) > parity {
+		return 0, ErrTooManyErrors
+	}
+
+	// Work on a copy, so the erased bytes are only zeroed on success.
+	work := append([]byte{}, codeword...)
+	erased := make(map[int]bool, len(erasures))
+
+	for _, e := range erasures {
+		if e < 0 || e >= len(codeword) || erased[e] {
+			return 0, ErrInvalidErasures
+		}
+
+		erased[e] = true
+		work[e] = 0
+	}
+
+	synd := syndromes(work, parity)
+
+	if isZero(synd) {
+		copy(codeword, work)
+
+		return 0, nil
+	}
+
+	fsynd := forneySyndromes(synd, erasures, len(work))
+	errLoc, err := errorLocator(fsynd, parity, len(erasures))
+
+	if err != nil {
+		return 0, err
+	}
+
+	errPos, err := findErrors(reverse(errLoc), len(work))
+
+	if err != nil {
+		return 0, err
+	}
+
+	// An error located at an erased position means the codeword is beyond
+	// repair, the Forney algorithm needs distinct positions.
+	for _, p := range errPos {
+		if erased[p] {
+			return 0, ErrTooManyErrors
+		}
+	}
+
+	positions := append(append([]int{}, erasures...), errPos...)
+	corrected, err := correctErrata(work, synd, positions)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !isZero(syndromes(corrected, parity)) {
+		return 0, ErrTooManyErrors
+	}
+
+	copy(codeword, corrected)
+
+	return len(positions), nil
+}
+
+// syndromes returns the syndromes of the codeword, prefixed with a zero
+// coefficient so their indexes line up with the decoder steps.
+func syndromes(codeword []byte, parity int) []byte {
+	synd := make([]byte, parity+1)
+
+	for i := 0; i < parity; i++ {
+		synd[i+1] = polyEval(codeword, gf256.Exp(i))
+	}
+
+	return synd
+}
+
+func isZero(p []byte) bool {
+	for _, c := range p {
+		if c != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// forneySyndromes returns the syndromes with the influence of the erasures removed.
+func forneySyndromes(synd []byte, erasures []int, n int) []byte {
+	fsynd := append([]byte{}, synd[1:]...)
+
+	for _, e := range erasures {
+		x := gf256.Exp(n - 1 - e)
+
+		for j := 0; j < len(fsynd)-1; j++ {
+			fsynd[j] = gf256.Mul(fsynd[j], x) ^ fsynd[j+1]
+		}
+	}
+
+	return fsynd
+}
+
+// errorLocator finds the locator polynomial of the unknown errors with the
+// Berlekamp-Massey algorithm.
+func errorLocator(synd []byte, parity, erasureCount int) ([]byte, error) {
+	errLoc := []byte{1}
+	oldLoc := []byte{1}
+
+	shift := 0
+
+	if len(synd) > parity {
+		shift = len(synd) - parity
+	}
+
+	for i := 0; i < parity-erasureCount; i++ {
+		k := i + shift
+		delta := synd[k]
+
+		for j := 1; j < len(errLoc); j++ {
+			delta ^= gf256.Mul(errLoc[len(errLoc)-1-j], synd[k-j])
+		}
+
+		oldLoc = append(oldLoc, 0)
+
+		if delta != 0 {
+			if len(oldLoc) > len(errLoc) {
+				newLoc := polyScale(oldLoc, delta)
+				oldLoc = polyScale(errLoc, gf256.Inv(delta))
+				errLoc = newLoc
+			}
+
+			errLoc = polyAdd(errLoc, polyScale(oldLoc, delta))
+		}
+	}
+
+	for len(errLoc) > 0 && errLoc[0] == 0 {
+		errLoc = errLoc[1:]
+	}
+
+	errs := len(errLoc) - 1
+
+	if errs*2+erasureCount > parity {
+		return nil, ErrTooManyErrors
+	}
+
+	return errLoc, nil
+}
+
+// findErrors returns the codeword indexes of the roots of the error locator
+// with a Chien search.
+func findErrors(errLoc []byte, n int) ([]int, error) {
+	var positions []int
+
+	for i := 0; i < n; i++ {
+		if polyEval(errLoc, gf256.Exp(i)) == 0 {
+			positions = append(positions, n-1-i)
+		}
+	}
+
+	if len(positions) != len(errLoc)-1 {
+		return nil, ErrTooManyErrors
+	}
+
+	return positions, nil
+}
+
+// errataLocator returns the locator polynomial for the given coefficient positions.
+func errataLocator(coefPos []int) []byte {
+	loc := []byte{1}
+
+	for _, p := range coefPos {
+		loc = polyMul(loc, polyAdd([]byte{1}, []byte{gf256.Exp(p), 0}))
+	}
+
+	return loc
+}
+
+// correctErrata returns a copy of the codeword with the errors at the given
+// indexes corrected by the Forney algorithm.
+func correctErrata(codeword, synd []byte, positions []int) ([]byte, error) {
+	coefPos := make([]int, len(positions))
+
+	for i, p := range positions {
+		coefPos[i] = len(codeword) - 1 - p
+	}
+
+	errLoc := errataLocator(coefPos)
+
+	// error evaluator omega(x) = synd(x) * errLoc(x) mod x^(len(errLoc))
+	divisor := make([]byte, len(errLoc)+1)
+	divisor[0] = 1
+	errEval := polyRemainder(polyMul(reverse(synd), errLoc), divisor)
+
+	x := make([]byte, len(coefPos))
+
+	for i, p := range coefPos {
+		x[i] = gf256.Exp(p)
+	}
+
+	magnitudes := make([]byte, len(codeword))
+
+	for i, xi := range x {
+		xiInv := gf256.Inv(xi)
+
+		// formal derivative of the errata locator evaluated at xiInv
+		errLocPrime := byte(1)
+
+		for j, xj := range x {
+			if j != i {
+				errLocPrime = gf256.Mul(errLocPrime, 1^gf256.Mul(xiInv, xj))
+			}
+		}
+
+		// A zero derivative means two positions coincide, which can not be corrected.
+		if errLocPrime == 0 {
+			return nil, ErrTooManyErrors
+		}
+
+		y := gf256.Mul(xi, polyEval(errEval, xiInv))
+		magnitudes[positions[i]] = gf256.Div(y, errLocPrime)
+	}
+
+	return polyAdd(codeword, magnitudes), nil
+}
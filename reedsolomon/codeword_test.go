@@ -0,0 +1,155 @@
+package reedsolomon
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestParityKnownValue(t *testing.T) {
+	// QR code version 1-M example from ISO 18004 Annex I, "01234567"
+	data := []byte{0x10, 0x20, 0x0c, 0x56, 0x61, 0x80, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11}
+	expected := []byte{0xa5, 0x24, 0xd4, 0xc1, 0xed, 0x36, 0xc7, 0x87, 0x2c, 0x55}
+
+	got, err := Parity(data, len(expected))
+
+	if err != nil {
+		t.Fatalf("Expected parity, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected parity %x, got %x", expected, got)
+	}
+}
+
+func TestCorrectErrorsAndErasures(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for run := 0; run < 200; run++ {
+		parity := 2 + rng.Intn(30)
+		data := make([]byte, 1+rng.Intn(MaxCodewordLength-parity))
+		rng.Read(data)
+
+		p, _ := Parity(data, parity)
+		expected := append(append([]byte{}, data...), p...)
+
+		// spend the parity budget on a random mix of errors and erasures
+		errs := rng.Intn(parity/2 + 1)
+		erasureCount := rng.Intn(parity - 2*errs + 1)
+		positions := rng.Perm(len(expected))[:errs+erasureCount]
+
+		codeword := append([]byte{}, expected...)
+
+		for _, pos := range positions {
+			codeword[pos] ^= byte(1 + rng.Intn(255))
+		}
+
+		if _, err := Correct(codeword, parity, positions[errs:]); err != nil {
+			t.Fatalf("Expected %d errors and %d erasures to be corrected with %d parity bytes, got error \"%s\"", errs, erasureCount, parity, err)
+		}
+
+		if !bytes.Equal(codeword, expected) {
+			t.Fatalf("Expected corrected codeword to equal the original")
+		}
+	}
+}
+
+func TestCorrectTooManyErrors(t *testing.T) {
+	data := []byte("Hello, Reed-Solomon!")
+	p, _ := Parity(data, 4)
+	codeword := append(append([]byte{}, data...), p...)
+
+	codeword[0] ^= 1
+	codeword[5] ^= 1
+	codeword[10] ^= 1
+
+	_, err := Correct(codeword, 4, nil)
+
+	if err != ErrTooManyErrors {
+		t.Errorf("Expected ErrTooManyErrors, got \"%v\"", err)
+	}
+}
+
+func TestCorrectTooManyErasures(t *testing.T) {
+	codeword := make([]byte, 10)
+
+	_, err := Correct(codeword, 2, []int{0, 1, 2})
+
+	if err != ErrTooManyErrors {
+		t.Errorf("Expected ErrTooManyErrors, got \"%v\"", err)
+	}
+}
+
+func TestParityInvalidLength(t *testing.T) {
+	if _, err := Parity([]byte("data"), 0); err != ErrInvalidParity {
+		t.Errorf("Expected ErrInvalidParity, got \"%v\"", err)
+	}
+
+	if _, err := Parity(make([]byte, 250), 10); err != ErrCodewordTooLong {
+		t.Errorf("Expected ErrCodewordTooLong, got \"%v\"", err)
+	}
+}
+
+func TestCorrectBeyondCapacity(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for run := 0; run < 20000; run++ {
+		parity := 2 + rng.Intn(12)
+		data := make([]byte, 1+rng.Intn(40))
+		rng.Read(data)
+
+		p, _ := Parity(data, parity)
+		codeword := append(append([]byte{}, data...), p...)
+
+		// corrupt more bytes than the parity can fix, part of them as erasures,
+		// the decoder has to report that instead of panicking
+		positions := rng.Perm(len(codeword))[:parity/2+1+rng.Intn(len(codeword)-parity/2)]
+		erasureCount := rng.Intn(len(positions) + 1)
+
+		if erasureCount > parity {
+			erasureCount = parity
+		}
+
+		for _, pos := range positions {
+			codeword[pos] ^= byte(1 + rng.Intn(255))
+		}
+
+		original := append([]byte{}, codeword...)
+
+		if _, err := Correct(codeword, parity, positions[:erasureCount]); err != nil && !bytes.Equal(codeword, original) {
+			t.Fatalf("Expected codeword to be unchanged on failure")
+		}
+	}
+}
+
+func TestCorrectDecodeRegression(t *testing.T) {
+	// used to panic with a division by zero in the Forney algorithm
+	_, err := Decode([]byte("EH8FLNYUBEaK/FV *Q/52Z05+NRZRS%%M YU0ST43BJNQ2a"), 5)
+
+	if err != ErrTooManyErrors {
+		t.Errorf("Expected ErrTooManyErrors, got \"%v\"", err)
+	}
+}
+
+func TestCorrectInvalidErasures(t *testing.T) {
+	for _, erasures := range [][]int{{3, 3}, {-1}, {10}} {
+		codeword := make([]byte, 10)
+
+		if _, err := Correct(codeword, 6, erasures); err != ErrInvalidErasures {
+			t.Errorf("Expected ErrInvalidErasures for %v, got \"%v\"", erasures, err)
+		}
+	}
+}
+
+func TestCorrectKeepsInputOnFailure(t *testing.T) {
+	codeword := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	original := append([]byte{}, codeword...)
+
+	if _, err := Correct(codeword, 2, []int{0}); err == nil {
+		t.Fatalf("Expected an error for random bytes")
+	}
+
+	if !bytes.Equal(codeword, original) {
+		t.Errorf("Expected codeword to be unchanged, got %v", codeword)
+	}
+}
@@ -0,0 +1,18 @@
+package reedsolomon
+
+import "errors"
+
+// ErrInvalidParity means the parity length is not between 1 and the maximum
+// the codeword length allows.
+var ErrInvalidParity = errors.New("invalid parity length")
+
+// ErrCodewordTooLong means data and parity exceed the 255 bytes of a codeword.
+var ErrCodewordTooLong = errors.New("codeword too long")
+
+// ErrTooManyErrors means the codeword contains more errors and erasures than
+// the parity bytes are able to correct.
+var ErrTooManyErrors = errors.New("too many errors to correct")
+
+// ErrInvalidErasures means the erasure indexes contain duplicates or indexes
+// outside of the codeword.
+var ErrInvalidErasures = errors.New("invalid erasure positions")
@@ -0,0 +1,88 @@
+package reedsolomon
+
+import "github.com/adrianrudnik/base45-go/internal/gf256"
+
+// Polynomials are stored with the coefficient of the highest degree first,
+// which matches the byte order of a codeword.
+
+func polyAdd(p, q []byte) []byte {
+	n := len(p)
+
+	if len(q) > n {
+		n = len(q)
+	}
+
+	r := make([]byte, n)
+
+	for i, c := range p {
+		r[i+n-len(p)] = c
+	}
+
+	for i, c := range q {
+		r[i+n-len(q)] ^= c
+	}
+
+	return r
+}
+
+func polyMul(p, q []byte) []byte {
+	r := make([]byte, len(p)+len(q)-1)
+
+	for j, b := range q {
+		for i, a := range p {
+			r[i+j] ^= gf256.Mul(a, b)
+		}
+	}
+
+	return r
+}
+
+func polyScale(p []byte, x byte) []byte {
+	r := make([]byte, len(p))
+
+	for i, c := range p {
+		r[i] = gf256.Mul(c, x)
+	}
+
+	return r
+}
+
+// polyEval evaluates p at x with Horner's method.
+func polyEval(p []byte, x byte) byte {
+	y := p[0]
+
+	for _, c := range p[1:] {
+		y = gf256.Mul(y, x) ^ c
+	}
+
+	return y
+}
+
+// polyRemainder returns the remainder of the division of p by the monic divisor.
+func polyRemainder(p, divisor []byte) []byte {
+	r := append([]byte{}, p...)
+
+	for i := 0; i < len(p)-len(divisor)+1; i++ {
+		coef := r[i]
+
+		if coef == 0 {
+			continue
+		}
+
+		for j := 1; j < len(divisor); j++ {
+			r[i+j] ^= gf256.Mul(divisor[j], coef)
+		}
+	}
+
+	return r[len(r)-len(divisor)+1:]
+}
+
+func reverse(p []byte) []byte {
+	r := make([]byte, len(p))
+
+	for i, c := range p {
+		r[len(p)-1-i] = c
+	}
+
+	return r
+}
@@ -0,0 +1,206 @@
+// Package reedsolomon adds an optional Reed-Solomon error correction layer over
+// GF(256) on top of base 45, for channels like OCR or voice readouts where
+// single characters get lost or misread.
+//
+// The parity bytes are appended to the data before it is base 45 encoded.
+// Data longer than a single codeword allows is split into the fewest blocks
+// that fit, each with its own parity bytes. The first blocks are one byte
+// shorter than the last ones if the data does not split evenly. Like the
+// blocks of a QR code, the codewords are interleaved byte by byte, data first
+// and parity last, so a burst of lost characters is spread across all blocks.
+// The block layout is derived from the encoded length and the parity, so it
+// does not need to be transmitted.
+//
+// On decoding, every character triplet (or trailing pair) that contains
+// characters outside the alphabet or overflows, the cases reported as
+// ErrInvalidEncodingCharacters and ErrInvalidEncodedDataOverflow by
+// base45.Decode, is treated as an erasure of the bytes it encodes. Erasures
+// cost one parity byte each, misread but valid characters are unknown errors
+// affecting up to two bytes, which cost two parity bytes each. Both budgets
+// apply per block.
+package reedsolomon
+
+import (
+	"github.com/adrianrudnik/base45-go"
+)
+
+// position is the location of an interleaved byte within the codewords.
+type position struct {
+	block, index int
+}
+
+// Encode appends the given number of parity bytes to each block of the data,
+// interleaves the codewords and encodes the result to base 45.
+func Encode(data []byte, parity int) ([]byte, error) {
+	if parity < 1 || parity >= MaxCodewordLength {
+		return nil, ErrInvalidParity
+	}
+
+	lengths := blockLengths(len(data), parity)
+	codewords := make([][]byte, len(lengths))
+
+	for b, k := 0, 0; b < len(lengths); b++ {
+		block := data[k : k+lengths[b]]
+		k += lengths[b]
+
+		p, err := Parity(block, parity)
+
+		if err != nil {
+			return nil, err
+		}
+
+		codewords[b] = append(append([]byte{}, block...), p...)
+	}
+
+	out := make([]byte, 0, len(data)+len(lengths)*parity)
+
+	for _, pos := range layout(lengths, parity) {
+		out = append(out, codewords[pos.block][pos.index])
+	}
+
+	return base45.Encode(out), nil
+}
+
+// Decode decodes the given base 45 input, corrects errors and erasures with
+// the given number of parity bytes per block and returns the data without
+// parity. If any block can not be corrected, ErrTooManyErrors is returned.
+func Decode(in []byte, parity int) ([]byte, error) {
+	interleaved, erasures, err := decodeWithErasures(in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parity < 1 || parity >= MaxCodewordLength {
+		return nil, ErrInvalidParity
+	}
+
+	// Encode uses the fewest blocks possible, so every block but the last
+	// set of bytes is a full codeword.
+	numBlocks := (len(interleaved) + MaxCodewordLength - 1) / MaxCodewordLength
+	dataLen := len(interleaved) - numBlocks*parity
+
+	if dataLen < 1 || len(blockLengths(dataLen, parity)) != numBlocks {
+		return nil, ErrInvalidParity
+	}
+
+	lengths := blockLengths(dataLen, parity)
+	positions := layout(lengths, parity)
+
+	codewords := make([][]byte, numBlocks)
+	blockErasures := make([][]int, numBlocks)
+
+	for b, n := range lengths {
+		codewords[b] = make([]byte, n+parity)
+	}
+
+	for i, pos := range positions {
+		codewords[pos.block][pos.index] = interleaved[i]
+	}
+
+	for _, e := range erasures {
+		pos := positions[e]
+		blockErasures[pos.block] = append(blockErasures[pos.block], pos.index)
+	}
+
+	out := make([]byte, 0, dataLen)
+
+	for b, codeword := range codewords {
+		if _, err := Correct(codeword, parity, blockErasures[b]); err != nil {
+			return nil, err
+		}
+
+		out = append(out, codeword[:lengths[b]]...)
+	}
+
+	return out, nil
+}
+
+// blockLengths splits dataLen bytes into the fewest blocks that fit into a
+// codeword with the given parity. If the data does not split evenly, the
+// first blocks are one byte shorter than the last ones.
+func blockLengths(dataLen, parity int) []int {
+	maxData := MaxCodewordLength - parity
+	numBlocks := (dataLen + maxData - 1) / maxData
+
+	if numBlocks == 0 {
+		numBlocks = 1
+	}
+
+	numShortBlocks := numBlocks - dataLen%numBlocks
+	lengths := make([]int, numBlocks)
+
+	for b := range lengths {
+		lengths[b] = dataLen / numBlocks
+
+		if b >= numShortBlocks {
+			lengths[b]++
+		}
+	}
+
+	return lengths
+}
+
+// layout returns the codeword position of every interleaved byte. The data
+// bytes are taken column by column across all blocks, followed by the parity
+// bytes in the same way.
+func layout(lengths []int, parity int) []position {
+	var out []position
+
+	for i := 0; i < lengths[len(lengths)-1]; i++ {
+		for b, n := range lengths {
+			if i < n {
+				out = append(out, position{b, i})
+			}
+		}
+	}
+
+	for i := 0; i < parity; i++ {
+		for b, n := range lengths {
+			out = append(out, position{b, n + i})
+		}
+	}
+
+	return out
+}
+
+// decodeWithErasures decodes the base 45 input group by group. Bytes of groups
+// that fail to decode are zeroed and their indexes returned as erasures.
+func decodeWithErasures(in []byte) ([]byte, []int, error) {
+	if len(in) == 0 {
+		return nil, nil, base45.ErrEmptyInput
+	}
+
+	// A missing or additional character shifts all following groups, which
+	// can not be recovered from.
+	if len(in)%3 == 1 {
+		return nil, nil, base45.ErrInvalidLength
+	}
+
+	out := make([]byte, 0, len(in)/3*2+1)
+	var erasures []int
+
+	for i := 0; i < len(in); i += 3 {
+		end := i + 3
+
+		if end > len(in) {
+			end = len(in)
+		}
+
+		// Three characters encode two bytes, a trailing pair one byte.
+		size := end - i - 1
+		dec, err := base45.Decode(in[i:end])
+
+		if err != nil {
+			for j := 0; j < size; j++ {
+				erasures = append(erasures, len(out)+j)
+			}
+
+			dec = make([]byte, size)
+		}
+
+		out = append(out, dec...)
+	}
+
+	return out, erasures, nil
+}
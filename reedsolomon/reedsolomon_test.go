@@ -0,0 +1,156 @@
+package reedsolomon
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	expected := []byte("Hello!!")
+
+	enc, err := Encode(expected, 4)
+
+	if err != nil {
+		t.Fatalf("Expected encoded data, got error \"%s\"", err)
+	}
+
+	got, err := Decode(enc, 4)
+
+	if err != nil {
+		t.Fatalf("Expected decoded data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected \"%s\", got \"%s\"", expected, got)
+	}
+}
+
+func TestEncodeDecodeMultipleBlocks(t *testing.T) {
+	expected := make([]byte, 1000)
+	rand.New(rand.NewSource(1)).Read(expected)
+
+	if n := len(blockLengths(len(expected), 20)); n != 5 {
+		t.Fatalf("Expected 5 blocks, got %d", n)
+	}
+
+	enc, err := Encode(expected, 20)
+
+	if err != nil {
+		t.Fatalf("Expected encoded data, got error \"%s\"", err)
+	}
+
+	// A burst of 10 unreadable groups erases 20 consecutive bytes, which
+	// interleaving spreads as 4 erasures over each of the 5 blocks.
+	for i := 300; i < 330; i++ {
+		enc[i] = '?'
+	}
+
+	// Single unreadable groups and misread characters elsewhere.
+	for _, i := range []int{0, 603, 1203, 1647} {
+		enc[i] = '?'
+	}
+
+	for _, i := range []int{901, 1401} {
+		enc[i] = swapCharacter(enc[i])
+	}
+
+	got, err := Decode(enc, 20)
+
+	if err != nil {
+		t.Fatalf("Expected decoded data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected decoded data to match the input")
+	}
+}
+
+func TestDecodeInvalidCharactersAsErasures(t *testing.T) {
+	expected := []byte("Hello, base45 with parity!")
+	enc, _ := Encode(expected, 8)
+
+	// lowercase letters are outside the alphabet, so both triplets are erased
+	enc[0] = 'o'
+	enc[10] = 'i'
+
+	if _, err := base45.Decode(enc); err != base45.ErrInvalidEncodingCharacters {
+		t.Fatalf("Expected plain decoding to fail, got \"%v\"", err)
+	}
+
+	got, err := Decode(enc, 8)
+
+	if err != nil {
+		t.Fatalf("Expected corrected data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected \"%s\", got \"%s\"", expected, got)
+	}
+}
+
+func TestDecodeOverflowAsErasure(t *testing.T) {
+	expected := []byte("Hello, base45 with parity!")
+	enc, _ := Encode(expected, 4)
+	copy(enc[3:6], "GGW")
+
+	got, err := Decode(enc, 4)
+
+	if err != nil {
+		t.Fatalf("Expected corrected data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected \"%s\", got \"%s\"", expected, got)
+	}
+}
+
+func TestDecodeMisreadCharacters(t *testing.T) {
+	expected := []byte("Hello, base45 with parity!")
+	enc, _ := Encode(expected, 8)
+
+	// valid but wrong characters are unknown errors
+	enc[1] = swapCharacter(enc[1])
+	enc[20] = swapCharacter(enc[20])
+
+	got, err := Decode(enc, 8)
+
+	if err != nil {
+		t.Fatalf("Expected corrected data, got error \"%s\"", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Expected \"%s\", got \"%s\"", expected, got)
+	}
+}
+
+func TestDecodeTooManyErrors(t *testing.T) {
+	enc, _ := Encode([]byte("Hello, base45 with parity!"), 2)
+	enc[0] = 'o'
+	enc[10] = 'i'
+
+	_, err := Decode(enc, 2)
+
+	if err != ErrTooManyErrors {
+		t.Errorf("Expected ErrTooManyErrors, got \"%v\"", err)
+	}
+}
+
+func TestDecodeInvalidLength(t *testing.T) {
+	enc, _ := Encode([]byte("Hello!!"), 4)
+
+	_, err := Decode(enc[1:], 4)
+
+	if err != base45.ErrInvalidLength {
+		t.Errorf("Expected ErrInvalidLength, got \"%v\"", err)
+	}
+}
+
+// swapCharacter returns a different character of the alphabet.
+func swapCharacter(c byte) byte {
+	i := bytes.IndexByte(base45.Alphabet, c)
+
+	return base45.Alphabet[(i+1)%len(base45.Alphabet)]
+}
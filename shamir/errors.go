@@ -0,0 +1,24 @@
+package shamir
+
+import "errors"
+
+// ErrEmptyInput means that there is no secret to split.
+var ErrEmptyInput = errors.New("empty input value")
+
+// ErrInvalidThreshold means the share count or threshold is out of range.
+// The threshold must be at least 2 and not exceed the share count, which is
+// limited to 255.
+var ErrInvalidThreshold = errors.New("invalid share count or threshold")
+
+// ErrInvalidShare means a share is too short or carries invalid header values.
+var ErrInvalidShare = errors.New("invalid share")
+
+// ErrSetMismatch means the shares belong to different sets, by their set ID,
+// threshold or secret length.
+var ErrSetMismatch = errors.New("shares belong to different sets")
+
+// ErrDuplicateShare means two shares carry the same index but different values.
+var ErrDuplicateShare = errors.New("conflicting shares with the same index")
+
+// ErrNotEnoughShares means fewer distinct shares than the threshold were given.
+var ErrNotEnoughShares = errors.New("not enough shares")
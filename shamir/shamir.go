@@ -0,0 +1,185 @@
+// Package shamir implements Shamir's secret sharing over GF(256), with the
+// shares encoded to base 45, e.g. to print them on separate QR cards.
+//
+// Every byte of the secret is shared with its own random polynomial of degree
+// threshold-1. Each share starts with a 6 byte header, followed by one byte
+// per secret byte:
+//
+//	set ID (4, big endian) | threshold (1) | index (1)
+//
+// The index is the x coordinate of the share and never 0, as the polynomials
+// evaluate to the secret there.
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/adrianrudnik/base45-go"
+	"github.com/adrianrudnik/base45-go/internal/gf256"
+)
+
+// HeaderSize is the size of the binary header of each share in bytes.
+const HeaderSize = 6
+
+// MaxShares is the maximum number of shares a secret can be split into.
+const MaxShares = 255
+
+// Split splits the secret into n base 45 encoded shares, any k of which are
+// needed to recombine it. The shares get a random set ID.
+func Split(secret []byte, n, k int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	if k < 2 || k > n || n > MaxShares {
+		return nil, ErrInvalidThreshold
+	}
+
+	setID := make([]byte, 4)
+
+	if _, err := rand.Read(setID); err != nil {
+		return nil, err
+	}
+
+	// coefficients[i] holds the random coefficients of degree 1 to k-1 of the
+	// polynomial for secret byte i, the coefficient of degree 0 is the byte itself.
+	coefficients := make([][]byte, len(secret))
+
+	for i := range coefficients {
+		coefficients[i] = make([]byte, k-1)
+
+		if _, err := rand.Read(coefficients[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	shares := make([][]byte, n)
+
+	for s := 0; s < n; s++ {
+		x := byte(s + 1)
+
+		share := make([]byte, HeaderSize, HeaderSize+len(secret))
+		copy(share[0:4], setID)
+		share[4] = byte(k)
+		share[5] = x
+
+		for i, b := range secret {
+			share = append(share, evaluate(b, coefficients[i], x))
+		}
+
+		shares[s] = base45.Encode(share)
+	}
+
+	return shares, nil
+}
+
+// evaluate evaluates the polynomial with the constant term c0 and the given
+// higher coefficients at x with Horner's method.
+func evaluate(c0 byte, coefficients []byte, x byte) byte {
+	y := byte(0)
+
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = gf256.Mul(y, x) ^ coefficients[i]
+	}
+
+	return gf256.Mul(y, x) ^ c0
+}
+
+// share is a decoded share.
+type share struct {
+	setID     uint32
+	threshold int
+	x         byte
+	y         []byte
+}
+
+// Combine decodes the given base 45 encoded shares and recombines the secret.
+// Duplicate shares are ignored, shares of different sets are rejected.
+func Combine(shares [][]byte) ([]byte, error) {
+	var distinct []share
+
+	for _, in := range shares {
+		s, err := decodeShare(in)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(distinct) > 0 {
+			first := distinct[0]
+
+			if s.setID != first.setID || s.threshold != first.threshold || len(s.y) != len(first.y) {
+				return nil, ErrSetMismatch
+			}
+		}
+
+		duplicate := false
+
+		for _, d := range distinct {
+			if d.x == s.x {
+				if !bytes.Equal(d.y, s.y) {
+					return nil, ErrDuplicateShare
+				}
+
+				duplicate = true
+			}
+		}
+
+		if !duplicate {
+			distinct = append(distinct, s)
+		}
+	}
+
+	if len(distinct) == 0 || len(distinct) < distinct[0].threshold {
+		return nil, ErrNotEnoughShares
+	}
+
+	distinct = distinct[:distinct[0].threshold]
+
+	// Lagrange interpolation at x = 0, where subtraction equals addition in GF(256).
+	secret := make([]byte, len(distinct[0].y))
+
+	for i, si := range distinct {
+		basis := byte(1)
+
+		for j, sj := range distinct {
+			if i != j {
+				basis = gf256.Mul(basis, gf256.Div(sj.x, sj.x^si.x))
+			}
+		}
+
+		for b := range secret {
+			secret[b] ^= gf256.Mul(si.y[b], basis)
+		}
+	}
+
+	return secret, nil
+}
+
+// decodeShare decodes and validates a single base 45 encoded share.
+func decodeShare(in []byte) (share, error) {
+	raw, err := base45.Decode(in)
+
+	if err != nil {
+		return share{}, err
+	}
+
+	if len(raw) <= HeaderSize {
+		return share{}, ErrInvalidShare
+	}
+
+	s := share{
+		setID:     binary.BigEndian.Uint32(raw[0:4]),
+		threshold: int(raw[4]),
+		x:         raw[5],
+		y:         raw[HeaderSize:],
+	}
+
+	if s.threshold < 2 || s.x == 0 {
+		return share{}, ErrInvalidShare
+	}
+
+	return s, nil
+}
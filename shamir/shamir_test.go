@@ -0,0 +1,106 @@
+package shamir
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/adrianrudnik/base45-go"
+)
+
+func TestSplitCombineAnySubset(t *testing.T) {
+	expected := []byte("correct horse battery staple")
+
+	shares, err := Split(expected, 5, 3)
+
+	if err != nil {
+		t.Fatalf("Expected shares, got error \"%s\"", err)
+	}
+
+	for run := 0; run < 20; run++ {
+		perm := rand.Perm(len(shares))
+		subset := [][]byte{shares[perm[0]], shares[perm[1]], shares[perm[2]]}
+
+		got, err := Combine(subset)
+
+		if err != nil {
+			t.Fatalf("Expected secret, got error \"%s\"", err)
+		}
+
+		if !bytes.Equal(got, expected) {
+			t.Errorf("Expected \"%s\", got \"%s\"", expected, got)
+		}
+	}
+}
+
+func TestSharesAreValidBase45(t *testing.T) {
+	shares, _ := Split([]byte("secret"), 3, 2)
+
+	for i, s := range shares {
+		if _, err := base45.Decode(s); err != nil {
+			t.Errorf("Expected share %d to be valid base 45, got error \"%s\"", i, err)
+		}
+	}
+}
+
+func TestCombineBelowThreshold(t *testing.T) {
+	shares, _ := Split([]byte("secret"), 5, 3)
+
+	_, err := Combine(shares[:2])
+
+	if err != ErrNotEnoughShares {
+		t.Errorf("Expected ErrNotEnoughShares, got \"%v\"", err)
+	}
+
+	// duplicates do not count towards the threshold
+	_, err = Combine([][]byte{shares[0], shares[1], shares[1]})
+
+	if err != ErrNotEnoughShares {
+		t.Errorf("Expected ErrNotEnoughShares for duplicates, got \"%v\"", err)
+	}
+}
+
+func TestCombineDifferentSets(t *testing.T) {
+	a, _ := Split([]byte("secret"), 3, 2)
+	b, _ := Split([]byte("secret"), 3, 2)
+
+	_, err := Combine([][]byte{a[0], b[1]})
+
+	if err != ErrSetMismatch {
+		t.Errorf("Expected ErrSetMismatch, got \"%v\"", err)
+	}
+}
+
+func TestCombineConflictingShares(t *testing.T) {
+	shares, _ := Split([]byte("secret"), 3, 2)
+	raw, _ := base45.Decode(shares[0])
+	raw[len(raw)-1] ^= 0x01
+
+	_, err := Combine([][]byte{shares[0], base45.Encode(raw)})
+
+	if err != ErrDuplicateShare {
+		t.Errorf("Expected ErrDuplicateShare, got \"%v\"", err)
+	}
+}
+
+func TestCombineInvalidShare(t *testing.T) {
+	_, err := Combine([][]byte{base45.Encode([]byte("short"))})
+
+	if err != ErrInvalidShare {
+		t.Errorf("Expected ErrInvalidShare, got \"%v\"", err)
+	}
+}
+
+func TestSplitInvalidThreshold(t *testing.T) {
+	for _, entry := range []struct{ n, k int }{{3, 1}, {3, 4}, {256, 2}} {
+		if _, err := Split([]byte("secret"), entry.n, entry.k); err != ErrInvalidThreshold {
+			t.Errorf("Expected ErrInvalidThreshold for %d of %d, got \"%v\"", entry.k, entry.n, err)
+		}
+	}
+}
+
+func TestSplitEmptyInput(t *testing.T) {
+	if _, err := Split([]byte{}, 3, 2); err != ErrEmptyInput {
+		t.Errorf("Expected ErrEmptyInput, got \"%v\"", err)
+	}
+}
@@ -0,0 +1,204 @@
+package base45
+
+import (
+	"bytes"
+	"sort"
+)
+
+// SuggestionKind describes the edit a suggestion applies to the input.
+type SuggestionKind int
+
+const (
+	// Substitution replaces a single character.
+	Substitution SuggestionKind = iota
+
+	// Transposition swaps two adjacent characters.
+	Transposition
+)
+
+// Suggestion is a likely correction of a base 45 string that failed to decode.
+type Suggestion struct {
+	// Kind is the edit applied to the input.
+	Kind SuggestionKind
+
+	// Position is the index of the replaced character, or of the first of the
+	// two swapped characters.
+	Position int
+
+	// Encoded is the corrected base 45 string.
+	Encoded []byte
+
+	// Decoded is the decoded value of the corrected string.
+	Decoded []byte
+
+	// Score is the relative likelihood of the edit, higher is more likely.
+	Score float64
+}
+
+// Scores of the different edits, based on how likely they happen when a code
+// is typed by hand or read by OCR.
+const (
+	scoreCaseConfusion  = 1.0
+	scoreShapeConfusion = 0.8
+	scoreTransposition  = 0.1
+	scoreSubstitution   = 0.01
+)
+
+// shapeConfusions lists characters that are commonly mistaken for each other.
+var shapeConfusions = map[byte][]byte{
+	'0': {'O', 'D', 'Q'},
+	'O': {'0', 'D', 'Q'},
+	'D': {'0', 'O'},
+	'Q': {'0', 'O'},
+	'1': {'I', 'L', 'T'},
+	'I': {'1', 'L'},
+	'L': {'1', 'I'},
+	'T': {'1'},
+	'2': {'Z'},
+	'Z': {'2'},
+	'5': {'S'},
+	'S': {'5'},
+	'6': {'G'},
+	'G': {'6'},
+	'8': {'B'},
+	'B': {'8'},
+	'U': {'V'},
+	'V': {'U'},
+	'l': {'1', 'I'},
+	'o': {'0', 'O'},
+	'_': {'-'},
+	',': {'.'},
+}
+
+// Suggest enumerates single character substitutions and transpositions of
+// adjacent characters that turn the input into a decodable base 45 string,
+// ranked by likelihood. Common confusions like O/0, I/1, S/5 and B/8 and
+// lowercase letters rank first.
+//
+// If accept is not nil, only corrections whose decoded value it accepts are
+// returned, e.g. to keep only payloads with a valid signature. If the input
+// decodes fine, every edit is tried, which only makes sense with accept set.
+func Suggest(in []byte, accept func(decoded []byte) bool) []Suggestion {
+	// A single edit can not fix an invalid length.
+	if len(in) == 0 || (len(in)%3 != 0 && (len(in)+1)%3 != 0) {
+		return nil
+	}
+
+	failing := failingGroups(in)
+
+	// An edit touches at most two neighbouring groups.
+	if len(failing) > 2 || (len(failing) == 2 && failing[1] != failing[0]+1) {
+		return nil
+	}
+
+	touches := func(pos int) bool {
+		if len(failing) == 0 {
+			return true
+		}
+
+		for _, g := range failing {
+			if pos/3 == g {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var out []Suggestion
+
+	try := func(kind SuggestionKind, pos int, candidate []byte, score float64) {
+		dec, err := Decode(candidate)
+
+		if err != nil || (accept != nil && !accept(dec)) {
+			return
+		}
+
+		out = append(out, Suggestion{
+			Kind:     kind,
+			Position: pos,
+			Encoded:  candidate,
+			Decoded:  dec,
+			Score:    score,
+		})
+	}
+
+	for pos := range in {
+		if !touches(pos) || len(failing) == 2 {
+			continue
+		}
+
+		for _, c := range Alphabet {
+			if c == in[pos] {
+				continue
+			}
+
+			candidate := append([]byte{}, in...)
+			candidate[pos] = c
+
+			try(Substitution, pos, candidate, substitutionScore(in[pos], c))
+		}
+	}
+
+	for pos := 0; pos+1 < len(in); pos++ {
+		if in[pos] == in[pos+1] || !(touches(pos) || touches(pos+1)) {
+			continue
+		}
+
+		if len(failing) == 2 && !(touches(pos) && touches(pos+1)) {
+			continue
+		}
+
+		candidate := append([]byte{}, in...)
+		candidate[pos], candidate[pos+1] = candidate[pos+1], candidate[pos]
+
+		try(Transposition, pos, candidate, scoreTransposition)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+
+		if out[i].Position != out[j].Position {
+			return out[i].Position < out[j].Position
+		}
+
+		return bytes.Compare(out[i].Encoded, out[j].Encoded) < 0
+	})
+
+	return out
+}
+
+// failingGroups returns the indexes of the character triplets (or the trailing
+// pair) that contain invalid characters or overflow.
+func failingGroups(in []byte) []int {
+	var failing []int
+
+	for i := 0; i < len(in); i += 3 {
+		end := i + 3
+
+		if end > len(in) {
+			end = len(in)
+		}
+
+		if _, err := Decode(in[i:end]); err != nil {
+			failing = append(failing, i/3)
+		}
+	}
+
+	return failing
+}
+
+// substitutionScore returns the likelihood of from being a misread of to.
+func substitutionScore(from, to byte) float64 {
+	if from >= 'a' && from <= 'z' && from-'a'+'A' == to {
+		return scoreCaseConfusion
+	}
+
+	if bytes.IndexByte(shapeConfusions[from], to) >= 0 {
+		return scoreShapeConfusion
+	}
+
+	return scoreSubstitution
+}
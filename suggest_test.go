@@ -0,0 +1,79 @@
+package base45
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSuggestInvalidCharacter(t *testing.T) {
+	got := Suggest([]byte("%69 VD92EXo"), nil)
+
+	if len(got) == 0 {
+		t.Fatalf("Expected suggestions, got none")
+	}
+
+	if !bytes.Equal(got[0].Encoded, []byte("%69 VD92EX0")) || got[0].Kind != Substitution || got[0].Position != 10 {
+		t.Errorf("Expected \"%%69 VD92EX0\" as top suggestion, got %+v", got[0])
+	}
+
+	if !bytes.Equal(got[0].Decoded, []byte("Hello!!")) {
+		t.Errorf("Expected decoded \"Hello!!\", got \"%s\"", got[0].Decoded)
+	}
+}
+
+func TestSuggestOverflow(t *testing.T) {
+	got := Suggest([]byte("GGW"), nil)
+
+	if len(got) == 0 {
+		t.Fatalf("Expected suggestions, got none")
+	}
+
+	if got[0].Score != scoreShapeConfusion {
+		t.Errorf("Expected a shape confusion as top suggestion, got %+v", got[0])
+	}
+
+	found := false
+
+	for i, s := range got {
+		if i > 0 && s.Score > got[i-1].Score {
+			t.Errorf("Expected suggestions to be ranked by score")
+		}
+
+		if bytes.Equal(s.Encoded, []byte("FGW")) {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected \"FGW\" to be suggested")
+	}
+}
+
+func TestSuggestWithPredicate(t *testing.T) {
+	accept := func(decoded []byte) bool {
+		return bytes.Equal(decoded, []byte("Hello!!"))
+	}
+
+	// The input decodes fine, but to the wrong value.
+	got := Suggest([]byte("%69 VD29EX0"), accept)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected exactly one suggestion, got %d", len(got))
+	}
+
+	if got[0].Kind != Transposition || got[0].Position != 6 {
+		t.Errorf("Expected transposition at position 6, got %+v", got[0])
+	}
+}
+
+func TestSuggestInvalidLength(t *testing.T) {
+	if got := Suggest([]byte("ABCD"), nil); got != nil {
+		t.Errorf("Expected no suggestions for an invalid length, got %d", len(got))
+	}
+}
+
+func TestSuggestTooManyFailures(t *testing.T) {
+	if got := Suggest([]byte("aaBBB8aa"), nil); got != nil {
+		t.Errorf("Expected no suggestions for distant failures, got %d", len(got))
+	}
+}